@@ -4,10 +4,6 @@ terraform {
       source  = "tehcyx/kind"
       version = "0.0.15"
     }
-    helm = {
-      source  = "hashicorp/helm"
-      version = "2.7.1"
-    }
     kubernetes = {
       source  = "hashicorp/kubernetes"
       version = "2.16.1"
@@ -19,20 +15,6 @@ terraform {
 }
 
 
-provider "helm" {
-  alias = "rome"
-  kubernetes {
-    config_path = kind_cluster.rome.kubeconfig_path
-  }
-
-}
-provider "helm" {
-  alias = "milan"
-  kubernetes {
-    config_path = kind_cluster.milan.kubeconfig_path
-  }
-
-}
 provider "kubernetes" {
   config_path = kind_cluster.rome.kubeconfig_path
 }
@@ -98,92 +80,44 @@ resource "kind_cluster" "milan" {
 }
 
 
-resource "helm_release" "install_liqo_rome" {
+resource "liqo_install" "rome" {
 
-  provider = helm.rome
+  provider = liqo.rome
 
-  name = "liqorome"
+  cluster_name        = "rome"
+  cluster_id_override = "cbea6d94-5d1e-4f48-85ad-7eb19e92d7e9"
+  cluster_labels = {
+    "liqo.io/provider" = "kind"
+  }
 
-  repository       = "https://helm.liqo.io/"
-  chart            = "liqo"
-  namespace        = "liqo"
-  create_namespace = true
+  pod_cidr     = "10.200.0.0/16"
+  service_cidr = "10.90.0.0/12"
 
-  set {
-    name  = "discovery.config.clusterName"
-    value = "rome"
-  }
-  set {
-    name  = "discovery.config.clusterIdOverride"
-    value = "cbea6d94-5d1e-4f48-85ad-7eb19e92d7e9"
-  }
-  set {
-    name  = "discovery.config.clusterLabels.liqo\\.io/provider"
-    value = "kind"
-  }
-  set {
-    name  = "auth.service.type"
-    value = "NodePort"
-  }
-  set {
-    name  = "gateway.service.type"
-    value = "NodePort"
-  }
-  set {
-    name  = "networkManager.config.serviceCIDR"
-    value = "10.90.0.0/12"
-  }
-  set {
-    name  = "networkManager.config.podCIDR"
-    value = "10.200.0.0/16"
-  }
+  auth_service_type    = "NodePort"
+  gateway_service_type = "NodePort"
 
 }
-resource "helm_release" "install_liqo_milan" {
+resource "liqo_install" "milan" {
 
-  provider = helm.milan
+  provider = liqo.milan
 
-  name = "liqomilan"
+  cluster_name        = "milan"
+  cluster_id_override = "36148485-d598-4d79-86fe-2559aba68d3c"
+  cluster_labels = {
+    "liqo.io/provider" = "kind"
+  }
 
-  repository       = "https://helm.liqo.io/"
-  chart            = "liqo"
-  namespace        = "liqo"
-  create_namespace = true
+  pod_cidr     = "10.200.0.0/16"
+  service_cidr = "10.90.0.0/12"
 
-  set {
-    name  = "discovery.config.clusterName"
-    value = "milan"
-  }
-  set {
-    name  = "discovery.config.clusterIdOverride"
-    value = "36148485-d598-4d79-86fe-2559aba68d3c"
-  }
-  set {
-    name  = "discovery.config.clusterLabels.liqo\\.io/provider"
-    value = "kind"
-  }
-  set {
-    name  = "auth.service.type"
-    value = "NodePort"
-  }
-  set {
-    name  = "gateway.service.type"
-    value = "NodePort"
-  }
-  set {
-    name  = "networkManager.config.serviceCIDR"
-    value = "10.90.0.0/12"
-  }
-  set {
-    name  = "networkManager.config.podCIDR"
-    value = "10.200.0.0/16"
-  }
+  auth_service_type    = "NodePort"
+  gateway_service_type = "NodePort"
 
 }
-resource "liqo_generate" "generate" {
+data "liqo_cluster_identity" "milan" {
 
   depends_on = [
-    helm_release.install_liqo_milan
+    liqo_install.milan
   ]
 
   provider = liqo.milan
@@ -192,15 +126,23 @@ resource "liqo_generate" "generate" {
 resource "liqo_peering" "peering" {
 
   depends_on = [
-    helm_release.install_liqo_rome
+    liqo_install.rome
   ]
 
   provider = liqo.rome
 
-  cluster_id      = liqo_generate.generate.cluster_id
-  cluster_name    = liqo_generate.generate.cluster_name
-  cluster_authurl = liqo_generate.generate.auth_ep
-  cluster_token   = liqo_generate.generate.local_token
+  cluster_id      = liqo_install.milan.cluster_id
+  cluster_name    = liqo_install.milan.cluster_name
+  cluster_authurl = data.liqo_cluster_identity.milan.auth_ep
+  cluster_token   = data.liqo_cluster_identity.milan.local_token
+
+  # The authentication service of kind clusters exposes a self-signed certificate.
+  insecure_skip_tls_verify = true
+
+  wait_for_established = true
+  timeouts = {
+    create = "5m"
+  }
 
 }
 resource "kubernetes_namespace" "namespace" {
@@ -216,7 +158,7 @@ resource "kubernetes_namespace" "namespace" {
 resource "liqo_offload" "offload" {
 
   depends_on = [
-    helm_release.install_liqo_rome,
+    liqo_install.rome,
     kubernetes_namespace.namespace
   ]
 
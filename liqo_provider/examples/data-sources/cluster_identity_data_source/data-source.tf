@@ -0,0 +1,2 @@
+# Read peer parameters.
+data "liqo_cluster_identity" "cluster_identity" {}
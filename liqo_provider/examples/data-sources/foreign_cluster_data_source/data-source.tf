@@ -0,0 +1,6 @@
+# Look up a ForeignCluster.
+data "liqo_foreign_cluster" "foreign_cluster" {
+
+  cluster_id = "<cluster_id>"
+
+}
@@ -0,0 +1,2 @@
+# List all the ForeignClusters.
+data "liqo_foreign_clusters" "foreign_clusters" {}
@@ -0,0 +1,16 @@
+# Check the network of a remote cluster before peering with it.
+data "liqo_network_check" "before_peering" {
+
+  remote_cluster = {
+    config_path = "path/to/remote/kubeconfig"
+  }
+
+}
+
+# Check how Liqo remapped the CIDRs of a peered cluster.
+data "liqo_network_check" "after_peering" {
+
+  cluster_id        = "<cluster_id>"
+  require_processed = true
+
+}
@@ -0,0 +1,12 @@
+# Read the resources offered by a peered cluster, warning if they are not enough.
+data "liqo_resource_offer" "milan" {
+
+  cluster_id = "<cluster_id>"
+
+  minimum = {
+    cpu    = "4"
+    memory = "8Gi"
+    pods   = "110"
+  }
+
+}
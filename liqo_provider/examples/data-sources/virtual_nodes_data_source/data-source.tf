@@ -0,0 +1,6 @@
+# List the virtual nodes of the remote clusters running on kind.
+data "liqo_virtual_nodes" "kind" {
+
+  label_selector = "liqo.io/provider=kind"
+
+}
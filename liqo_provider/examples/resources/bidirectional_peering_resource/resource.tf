@@ -0,0 +1,27 @@
+# Peer two clusters with each other, connecting to both of them.
+resource "liqo_bidirectional_peering" "peering" {
+
+  first_cluster = {
+    config_path = "path/to/first/kubeconfig"
+  }
+
+  second_cluster = {
+    config_path = "path/to/second/kubeconfig"
+  }
+
+}
+
+# Peer two clusters whose authentication services expose self-signed certificates (e.g. kind clusters).
+resource "liqo_bidirectional_peering" "peering_self_signed" {
+
+  first_cluster = {
+    config_path = "path/to/first/kubeconfig"
+  }
+
+  second_cluster = {
+    config_path = "path/to/second/kubeconfig"
+  }
+
+  insecure_skip_tls_verify = true
+
+}
@@ -0,0 +1,2 @@
+# Generate can be imported by the namespace where Liqo is installed.
+terraform import liqo_generate.generate liqo
@@ -0,0 +1,14 @@
+# Peer two clusters in-band, without exposing the authentication service of the remote cluster.
+resource "liqo_in_band_peering" "peering" {
+
+  local_cluster = {
+    config_path = "path/to/local/kubeconfig"
+  }
+
+  remote_cluster = {
+    config_path = "path/to/remote/kubeconfig"
+  }
+
+  bidirectional = true
+
+}
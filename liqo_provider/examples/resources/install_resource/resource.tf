@@ -0,0 +1,19 @@
+# Install Liqo in a kind cluster.
+resource "liqo_install" "install" {
+
+  cluster_name = "rome"
+  cluster_labels = {
+    "liqo.io/provider" = "kind"
+  }
+
+  pod_cidr     = "10.200.0.0/16"
+  service_cidr = "10.90.0.0/12"
+
+  auth_service_type    = "NodePort"
+  gateway_service_type = "NodePort"
+
+  timeouts = {
+    create = "10m"
+  }
+
+}
@@ -0,0 +1,2 @@
+# Offload can be imported by the name of the offloaded namespace.
+terraform import liqo_offload.offload <namespace>
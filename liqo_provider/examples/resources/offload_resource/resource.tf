@@ -6,6 +6,9 @@ resource "liqo_offload" "offload" {
   namespace_mapping_strategy = "DefaultName"
   cluster_selector_terms = [
     {
+      match_labels = {
+        "liqo.io/provider" = "kind"
+      }
       match_expressions = [
         {
           key      = "region"
@@ -15,5 +18,8 @@ resource "liqo_offload" "offload" {
       ]
     }
   ]
+  timeouts = {
+    delete = "10m"
+  }
 
 }
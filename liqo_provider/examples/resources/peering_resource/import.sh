@@ -0,0 +1,4 @@
+# Peering can be imported by the provider cluster ID, optionally prefixed by the Liqo namespace.
+# The authentication token is read from the Secret stored by Liqo.
+terraform import liqo_peering.peering <cluster_id>
+terraform import liqo_peering.peering <liqo_namespace>/<cluster_id>
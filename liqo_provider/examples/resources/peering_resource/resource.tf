@@ -7,3 +7,41 @@ resource "liqo_peering" "peering" {
   cluster_token   = "<cluster_token>"
 
 }
+
+# Peer two clusters reading the token from a Secret, so that it is not stored in the state.
+resource "liqo_peering" "peering_secret_ref" {
+
+  cluster_id      = "<cluster_id>"
+  cluster_name    = "<cluster_name>"
+  cluster_authurl = "<auth-url>"
+  cluster_token_secret_ref = {
+    name      = "<secret_name>"
+    namespace = "<secret_namespace>"
+    key       = "token"
+  }
+
+}
+
+# Peer two clusters verifying the authentication service with the CA exposed by liqo_generate.
+# The CA is not passed to Liqo, hence it must be trusted by the Liqo controller manager too.
+resource "liqo_peering" "peering_ca_bundle" {
+
+  cluster_id      = liqo_generate.generate.cluster_id
+  cluster_name    = liqo_generate.generate.cluster_name
+  cluster_authurl = liqo_generate.generate.auth_ep
+  cluster_token   = liqo_generate.generate.local_token
+  ca_bundle       = liqo_generate.generate.auth_ca
+
+}
+
+# Peer two clusters whose authentication service exposes a self-signed certificate.
+resource "liqo_peering" "peering_self_signed" {
+
+  cluster_id      = "<cluster_id>"
+  cluster_name    = "<cluster_name>"
+  cluster_authurl = "<auth-url>"
+  cluster_token   = "<cluster_token>"
+
+  insecure_skip_tls_verify = true
+
+}
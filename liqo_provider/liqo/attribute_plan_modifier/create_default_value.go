@@ -0,0 +1,46 @@
+package attribute_plan_modifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+type createDefaultValueAttributePlanModifier struct {
+	CreateValue   attr.Value
+	ExistingValue attr.Value
+}
+
+// CreateDefaultValue sets createValue as the default value of the attribute only for the resources being created,
+// the existing resources keep their current value, or existingValue if they were created before the attribute existed
+func CreateDefaultValue(createValue, existingValue attr.Value) tfsdk.AttributePlanModifier {
+	return &createDefaultValueAttributePlanModifier{createValue, existingValue}
+}
+
+var _ tfsdk.AttributePlanModifier = (*createDefaultValueAttributePlanModifier)(nil)
+
+func (apm *createDefaultValueAttributePlanModifier) Description(ctx context.Context) string {
+	return apm.MarkdownDescription(ctx)
+}
+
+func (apm *createDefaultValueAttributePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Sets the default value %q (%s) if the attribute is not set when the resource is created, "+
+		"otherwise keeps the current value", apm.CreateValue, apm.CreateValue.Type(ctx))
+}
+
+func (apm *createDefaultValueAttributePlanModifier) Modify(_ context.Context, req tfsdk.ModifyAttributePlanRequest, res *tfsdk.ModifyAttributePlanResponse) {
+	if !req.AttributeConfig.IsNull() {
+		return
+	}
+
+	switch {
+	case req.State.Raw.IsNull():
+		res.AttributePlan = apm.CreateValue
+	case req.AttributeState == nil || req.AttributeState.IsNull():
+		res.AttributePlan = apm.ExistingValue
+	default:
+		res.AttributePlan = req.AttributeState
+	}
+}
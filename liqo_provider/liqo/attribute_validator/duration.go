@@ -0,0 +1,46 @@
+package attribute_validator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type durationAttributeValidator struct{}
+
+func Duration() tfsdk.AttributeValidator {
+	return &durationAttributeValidator{}
+}
+
+var _ tfsdk.AttributeValidator = (*durationAttributeValidator)(nil)
+
+func (av *durationAttributeValidator) Description(ctx context.Context) string {
+	return av.MarkdownDescription(ctx)
+}
+
+func (av *durationAttributeValidator) MarkdownDescription(_ context.Context) string {
+	return "Value must be a positive duration string, such as \"30s\" or \"5m\""
+}
+
+func (av *durationAttributeValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, res *tfsdk.ValidateAttributeResponse) {
+	var value types.String
+	res.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeConfig, &value)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	if duration, err := time.ParseDuration(value.ValueString()); err != nil || duration <= 0 {
+		res.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Duration",
+			fmt.Sprintf("%s, got: %q", av.Description(ctx), value.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,524 @@
+package liqo
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-liqo/liqo/attribute_plan_modifier"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	discoveryv1alpha1 "github.com/liqotech/liqo/apis/discovery/v1alpha1"
+	foreigncluster "github.com/liqotech/liqo/pkg/utils/foreignCluster"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+var (
+	_ resource.Resource               = &bidirectionalPeeringResource{}
+	_ resource.ResourceWithModifyPlan = &bidirectionalPeeringResource{}
+)
+
+func NewBidirectionalPeeringResource() resource.Resource {
+	return &bidirectionalPeeringResource{}
+}
+
+// bidirectionalPeeringResource connects to both clusters on its own, hence it does not use the provider kubernetes Clients
+type bidirectionalPeeringResource struct {
+}
+
+func (b *bidirectionalPeeringResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bidirectional_peering"
+}
+
+func (b *bidirectionalPeeringResource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "Execute an out-of-band peering in both directions between two clusters.",
+		Attributes: map[string]tfsdk.Attribute{
+			"first_cluster": {
+				Required:    true,
+				Attributes:  tfsdk.SingleNestedAttributes(kubernetesAttributes()),
+				Description: "Connection to the first cluster.",
+			},
+			"first_liqo_namespace": {
+				Type:     types.StringType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.DefaultValue(types.StringValue("liqo")),
+					resource.RequiresReplace(),
+				},
+				Computed:    true,
+				Description: "Namespace where is Liqo installed in the first cluster.",
+			},
+			"second_cluster": {
+				Required:    true,
+				Attributes:  tfsdk.SingleNestedAttributes(kubernetesAttributes()),
+				Description: "Connection to the second cluster.",
+			},
+			"second_liqo_namespace": {
+				Type:     types.StringType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.DefaultValue(types.StringValue("liqo")),
+					resource.RequiresReplace(),
+				},
+				Computed:    true,
+				Description: "Namespace where is Liqo installed in the second cluster.",
+			},
+			"insecure_skip_tls_verify": {
+				Type:     types.BoolType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.CreateDefaultValue(types.BoolValue(false), types.BoolValue(true)),
+				},
+				Computed: true,
+				Description: "Skip the verification of the TLS certificates of the authentication services, a warning is emitted when enabled. Defaults to false for new peerings, while the existing ones keep their current setting. " +
+					"Otherwise, the authentication service of each cluster exposed through an Ingress with TLS is verified with its CA before peering. Such CA is only used by this check of the provider and is not passed to Liqo, hence self-signed authentication services still require insecure_skip_tls_verify = true.",
+			},
+			"timeouts": timeoutsAttribute(),
+			"first_cluster_id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "First cluster ID.",
+			},
+			"first_cluster_name": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "First cluster name.",
+			},
+			"second_cluster_id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Second cluster ID.",
+			},
+			"second_cluster_name": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Second cluster name.",
+			},
+		},
+	}, nil
+}
+
+// ModifyPlan warns when the verification of the TLS certificates of the authentication services is skipped,
+// and plans the replacement of the resource when the updated connections reach different clusters than the peered ones.
+// The latter check is skipped when the clusters are not reachable yet
+func (b *bidirectionalPeeringResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan bidirectionalPeeringResourceModel
+	if diags := req.Plan.Get(ctx, &plan); diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTLSVerification(path.Root("insecure_skip_tls_verify"), plan.InsecureSkipTLSVerify)...)
+
+	if req.State.Raw.IsNull() || req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	var state bidirectionalPeeringResourceModel
+	if diags := req.State.Get(ctx, &state); diags.HasError() {
+		return
+	}
+
+	first, second, err := plan.connect()
+	if err != nil {
+		return
+	}
+	if err := plan.generatePeeringParameters(ctx, first, second); err != nil {
+		return
+	}
+
+	if !plan.FirstClusterID.Equal(state.FirstClusterID) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("first_cluster"))
+	}
+	if !plan.SecondClusterID.Equal(state.SecondClusterID) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("second_cluster"))
+	}
+
+	diags := resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Creation of Bidirectional Peering Resource retrieves the peering parameters of both clusters, as "liqoctl generate peer-command" does,
+// and peers each cluster with the other one. If the peering of the second cluster fails, both sides are rolled back,
+// except for those which were already peered before
+func (b *bidirectionalPeeringResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan bidirectionalPeeringResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	first, second, err := plan.connect()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := plan.generatePeeringParameters(ctx, first, second); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	// The sides which were already peered before are left untouched by the rollback
+	firstPeered, err := first.outgoingPeeringEnabled(ctx, second)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	secondPeered, err := second.outgoingPeeringEnabled(ctx, first)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := first.peer(ctx, second); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			fmt.Sprintf("unable to peer the first cluster with the second one: %s", err),
+		)
+		return
+	}
+
+	if err := second.peer(ctx, first); err != nil {
+		detail := fmt.Sprintf("unable to peer the second cluster with the first one: %s", err)
+
+		// The second cluster may have already stored the authentication token, or created the ForeignCluster, before failing
+		if !secondPeered {
+			if _, rollbackErr := second.unpeer(ctx, first, plan.Timeouts.DeleteTimeout()); rollbackErr != nil {
+				detail += fmt.Sprintf(", the partial peering of the second cluster could not be rolled back: %s", rollbackErr)
+			}
+		}
+
+		if firstPeered {
+			detail += ", the first cluster was already peered with the second one and has been left untouched"
+		} else if _, rollbackErr := first.unpeer(ctx, second, plan.Timeouts.DeleteTimeout()); rollbackErr != nil {
+			detail += fmt.Sprintf(", the peering of the first cluster could not be rolled back: %s", rollbackErr)
+		} else {
+			detail += ", the peering of the first cluster has been rolled back"
+		}
+
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			detail,
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read checks that both clusters are still peered with each other,
+// the resource is removed from the state otherwise so that the missing side is peered again
+func (b *bidirectionalPeeringResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state bidirectionalPeeringResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	first, second, err := state.connect()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, side := range []struct{ local, remote *peeringSide }{{first, second}, {second, first}} {
+		CRClient, _, err := side.local.data.Clients()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Resource",
+				err.Error(),
+			)
+			return
+		}
+
+		fc, err := foreigncluster.GetForeignClusterByID(ctx, CRClient, side.remote.params.ClusterID)
+		if kerrors.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		} else if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Resource",
+				err.Error(),
+			)
+			return
+		}
+
+		if fc.Spec.OutgoingPeeringEnabled == discoveryv1alpha1.PeeringEnabledNo {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update of Bidirectional Peering Resource peers again both clusters, so that the authentication tokens and urls are refreshed,
+// the clusters reached through the updated connections must be the same as before
+func (b *bidirectionalPeeringResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan bidirectionalPeeringResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state bidirectionalPeeringResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	first, second, err := plan.connect()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := plan.generatePeeringParameters(ctx, first, second); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	if !plan.FirstClusterID.Equal(state.FirstClusterID) || !plan.SecondClusterID.Equal(state.SecondClusterID) {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			"The updated connections reach different clusters than the peered ones, the resource must be replaced.",
+		)
+		return
+	}
+
+	if err := first.peer(ctx, second); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			fmt.Sprintf("unable to peer the first cluster with the second one: %s", err),
+		)
+		return
+	}
+
+	if err := second.peer(ctx, first); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			fmt.Sprintf("unable to peer the second cluster with the first one: %s", err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete of Bidirectional Peering Resource tears down the peering of both clusters, starting from the second one
+func (b *bidirectionalPeeringResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state bidirectionalPeeringResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	first, second, err := state.connect()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, side := range []struct{ local, remote *peeringSide }{{second, first}, {first, second}} {
+		removed, err := side.local.unpeer(ctx, side.remote, state.Timeouts.DeleteTimeout())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Delete Resource",
+				err.Error(),
+			)
+			return
+		}
+
+		if !removed {
+			resp.Diagnostics.AddWarning(
+				"ForeignCluster Not Removed",
+				fmt.Sprintf("The ForeignCluster of the cluster %q and its authentication token were not removed from the cluster %q, "+
+					"as an incoming peering is still active.", side.remote.params.ClusterID, side.local.params.ClusterID),
+			)
+		}
+	}
+}
+
+// peeringSide is one of the two clusters of a bidirectional peering
+type peeringSide struct {
+	data                  *liqoProviderData
+	liqoNamespace         string
+	insecureSkipTLSVerify bool
+	params                peeringParameters
+}
+
+// peer peers the cluster with the remote one, as "liqoctl peer out-of-band" does
+func (s *peeringSide) peer(ctx context.Context, remote *peeringSide) error {
+	CRClient, KubeClient, err := s.data.Clients()
+	if err != nil {
+		return err
+	}
+
+	_, err = peerOutOfBand(ctx, CRClient, KubeClient, outOfBandPeering{
+		ClusterID:     remote.params.ClusterID,
+		ClusterName:   remote.params.ClusterName,
+		AuthURL:       remote.params.AuthEP,
+		Token:         remote.params.LocalToken,
+		LiqoNamespace: s.liqoNamespace,
+
+		InsecureSkipTLSVerify: s.insecureSkipTLSVerify,
+		CABundle:              remote.params.AuthCA,
+	})
+	return err
+}
+
+// outgoingPeeringEnabled checks whether the cluster has already enabled the outgoing peering towards the remote one
+func (s *peeringSide) outgoingPeeringEnabled(ctx context.Context, remote *peeringSide) (bool, error) {
+	CRClient, _, err := s.data.Clients()
+	if err != nil {
+		return false, err
+	}
+
+	fc, err := foreigncluster.GetForeignClusterByID(ctx, CRClient, remote.params.ClusterID)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return fc.Spec.OutgoingPeeringEnabled == discoveryv1alpha1.PeeringEnabledYes, nil
+}
+
+// unpeer tears down the peering of the cluster with the remote one, as "liqoctl unpeer out-of-band" does
+func (s *peeringSide) unpeer(ctx context.Context, remote *peeringSide, timeout time.Duration) (bool, error) {
+	CRClient, _, err := s.data.Clients()
+	if err != nil {
+		return false, err
+	}
+
+	return unpeerOutOfBand(ctx, CRClient, remote.params.ClusterID, s.liqoNamespace, timeout)
+}
+
+// connect prepares the kubernetes Clients of both clusters, the cluster identities known from the model are retained
+func (m *bidirectionalPeeringResourceModel) connect() (first, second *peeringSide, err error) {
+	firstData, err := newLiqoProviderData(liqoProviderModel{KUBERNETES: m.FirstCluster})
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid connection to the first cluster: %w", err)
+	}
+
+	secondData, err := newLiqoProviderData(liqoProviderModel{KUBERNETES: m.SecondCluster})
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid connection to the second cluster: %w", err)
+	}
+
+	first = &peeringSide{
+		data:                  firstData,
+		liqoNamespace:         m.FirstLiqoNamespace.ValueString(),
+		insecureSkipTLSVerify: m.InsecureSkipTLSVerify.ValueBool(),
+		params:                peeringParameters{ClusterID: m.FirstClusterID.ValueString(), ClusterName: m.FirstClusterName.ValueString()},
+	}
+	second = &peeringSide{
+		data:                  secondData,
+		liqoNamespace:         m.SecondLiqoNamespace.ValueString(),
+		insecureSkipTLSVerify: m.InsecureSkipTLSVerify.ValueBool(),
+		params:                peeringParameters{ClusterID: m.SecondClusterID.ValueString(), ClusterName: m.SecondClusterName.ValueString()},
+	}
+	return first, second, nil
+}
+
+// generatePeeringParameters retrieves the peering parameters of both clusters and copies their identities into the model
+func (m *bidirectionalPeeringResourceModel) generatePeeringParameters(ctx context.Context, first, second *peeringSide) error {
+	for _, side := range []*peeringSide{first, second} {
+		CRClient, _, err := side.data.Clients()
+		if err != nil {
+			return err
+		}
+
+		params, err := generatePeeringParameters(ctx, CRClient, side.liqoNamespace)
+		if err != nil {
+			return err
+		}
+		side.params = *params
+	}
+
+	m.FirstClusterID = types.StringValue(first.params.ClusterID)
+	m.FirstClusterName = types.StringValue(first.params.ClusterName)
+	m.SecondClusterID = types.StringValue(second.params.ClusterID)
+	m.SecondClusterName = types.StringValue(second.params.ClusterName)
+
+	if first.params.ClusterID == second.params.ClusterID {
+		return fmt.Errorf("both connections reach the same cluster %q", first.params.ClusterID)
+	}
+	return nil
+}
+
+type bidirectionalPeeringResourceModel struct {
+	FirstCluster        *kube_conf   `tfsdk:"first_cluster"`
+	FirstLiqoNamespace  types.String `tfsdk:"first_liqo_namespace"`
+	SecondCluster       *kube_conf   `tfsdk:"second_cluster"`
+	SecondLiqoNamespace types.String `tfsdk:"second_liqo_namespace"`
+
+	InsecureSkipTLSVerify types.Bool `tfsdk:"insecure_skip_tls_verify"`
+	Timeouts              *timeouts  `tfsdk:"timeouts"`
+
+	FirstClusterID    types.String `tfsdk:"first_cluster_id"`
+	FirstClusterName  types.String `tfsdk:"first_cluster_name"`
+	SecondClusterID   types.String `tfsdk:"second_cluster_id"`
+	SecondClusterName types.String `tfsdk:"second_cluster_name"`
+}
@@ -0,0 +1,130 @@
+package liqo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &clusterIdentityDataSource{}
+	_ datasource.DataSourceWithConfigure = &clusterIdentityDataSource{}
+)
+
+func NewClusterIdentityDataSource() datasource.DataSource {
+	return &clusterIdentityDataSource{}
+}
+
+type clusterIdentityDataSource struct {
+	data *liqoProviderData
+}
+
+func (d *clusterIdentityDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_identity"
+}
+
+func (d *clusterIdentityDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "Read peering parameters for remote clusters",
+		Attributes: map[string]tfsdk.Attribute{
+			"cluster_id": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Provider cluster ID.",
+			},
+			"cluster_name": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Provider cluster name.",
+			},
+			"auth_ep": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Provider authentication endpoint.",
+			},
+			"auth_ca": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "PEM-encoded CA certificate of the provider authentication service, read from the TLS Secret of its Ingress (null if it is not exposed through an Ingress with TLS).",
+			},
+			"local_token": {
+				Type:        types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Provider authentication token.",
+			},
+			"liqo_namespace": {
+				Type:        types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "Namespace where is Liqo installed in provider cluster. Defaults to \"liqo\".",
+			},
+		},
+	}, nil
+}
+
+// Read of Cluster Identity Data Source obtains at every refresh the pairing parameters used by Peering Resources,
+// with the same outputs of "liqoctl generate peer-command" command
+func (d *clusterIdentityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config clusterIdentityDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.LiqoNamespace.IsNull() {
+		config.LiqoNamespace = types.StringValue("liqo")
+	}
+
+	CRClient, _, err := d.data.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			err.Error(),
+		)
+		return
+	}
+
+	params, err := generatePeeringParameters(ctx, CRClient, config.LiqoNamespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			err.Error(),
+		)
+		return
+	}
+
+	config.ClusterID = types.StringValue(params.ClusterID)
+	config.ClusterName = types.StringValue(params.ClusterName)
+	config.LocalToken = types.StringValue(params.LocalToken)
+	config.AuthEP = types.StringValue(params.AuthEP)
+	config.AuthCA = authCAModel(params.AuthCA)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure method to obtain kubernetes Clients provided by provider
+func (d *clusterIdentityDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.data = req.ProviderData.(*liqoProviderData)
+}
+
+type clusterIdentityDataSourceModel struct {
+	ClusterID     types.String `tfsdk:"cluster_id"`
+	ClusterName   types.String `tfsdk:"cluster_name"`
+	AuthEP        types.String `tfsdk:"auth_ep"`
+	AuthCA        types.String `tfsdk:"auth_ca"`
+	LocalToken    types.String `tfsdk:"local_token"`
+	LiqoNamespace types.String `tfsdk:"liqo_namespace"`
+}
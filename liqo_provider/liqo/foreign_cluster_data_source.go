@@ -0,0 +1,194 @@
+package liqo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	discoveryv1alpha1 "github.com/liqotech/liqo/apis/discovery/v1alpha1"
+	foreigncluster "github.com/liqotech/liqo/pkg/utils/foreignCluster"
+	peeringconditionsutils "github.com/liqotech/liqo/pkg/utils/peeringConditions"
+	kubeTypes "k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	_ datasource.DataSource                     = &foreignClusterDataSource{}
+	_ datasource.DataSourceWithConfigure        = &foreignClusterDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &foreignClusterDataSource{}
+)
+
+func NewForeignClusterDataSource() datasource.DataSource {
+	return &foreignClusterDataSource{}
+}
+
+type foreignClusterDataSource struct {
+	data *liqoProviderData
+}
+
+func (d *foreignClusterDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_foreign_cluster"
+}
+
+func (d *foreignClusterDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "Look up a ForeignCluster by cluster ID or by name.",
+		Attributes:  foreignClusterAttributes(true),
+	}, nil
+}
+
+func (d *foreignClusterDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("cluster_id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *foreignClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config foreignClusterModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	CRClient, _, err := d.data.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			err.Error(),
+		)
+		return
+	}
+
+	fc := &discoveryv1alpha1.ForeignCluster{}
+	if !config.ClusterID.IsNull() {
+		fc, err = foreigncluster.GetForeignClusterByID(ctx, CRClient, config.ClusterID.ValueString())
+	} else {
+		err = CRClient.Get(ctx, kubeTypes.NamespacedName{Name: config.Name.ValueString()}, fc)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			err.Error(),
+		)
+		return
+	}
+
+	state := foreignClusterModelFrom(fc)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure method to obtain kubernetes Clients provided by provider
+func (d *foreignClusterDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.data = req.ProviderData.(*liqoProviderData)
+}
+
+// foreignClusterAttributes returns the attributes describing a ForeignCluster,
+// cluster_id and name can be set to look it up when lookup is true
+func foreignClusterAttributes(lookup bool) map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"cluster_id": {
+			Type:        types.StringType,
+			Optional:    lookup,
+			Computed:    true,
+			Description: "Foreign cluster ID.",
+		},
+		"name": {
+			Type:        types.StringType,
+			Optional:    lookup,
+			Computed:    true,
+			Description: "Name of the ForeignCluster resource.",
+		},
+		"cluster_name": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Foreign cluster name.",
+		},
+		"auth_url": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Foreign authentication url.",
+		},
+		"peering_type": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Type of the peering with the foreign cluster.",
+		},
+		"outgoing_peering_enabled": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Whether the outgoing peering towards the foreign cluster is enabled.",
+		},
+		"incoming_peering_enabled": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Whether the incoming peering from the foreign cluster is enabled.",
+		},
+		"authentication_status": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Status of the authentication with the foreign cluster.",
+		},
+		"incoming_peering_status": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Status of the incoming peering from the foreign cluster.",
+		},
+		"outgoing_peering_status": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Status of the outgoing peering towards the foreign cluster.",
+		},
+		"network_status": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Status of the network connectivity with the foreign cluster.",
+		},
+	}
+}
+
+// foreignClusterModelFrom converts a ForeignCluster into its data source representation
+func foreignClusterModelFrom(fc *discoveryv1alpha1.ForeignCluster) foreignClusterModel {
+	return foreignClusterModel{
+		ClusterID:              types.StringValue(fc.Spec.ClusterIdentity.ClusterID),
+		Name:                   types.StringValue(fc.Name),
+		ClusterName:            types.StringValue(fc.Spec.ClusterIdentity.ClusterName),
+		AuthURL:                types.StringValue(fc.Spec.ForeignAuthURL),
+		PeeringType:            types.StringValue(string(fc.Spec.PeeringType)),
+		OutgoingPeeringEnabled: types.StringValue(string(fc.Spec.OutgoingPeeringEnabled)),
+		IncomingPeeringEnabled: types.StringValue(string(fc.Spec.IncomingPeeringEnabled)),
+		AuthenticationStatus:   types.StringValue(string(peeringconditionsutils.GetStatus(fc, discoveryv1alpha1.AuthenticationStatusCondition))),
+		IncomingPeeringStatus:  types.StringValue(string(peeringconditionsutils.GetStatus(fc, discoveryv1alpha1.IncomingPeeringCondition))),
+		OutgoingPeeringStatus:  types.StringValue(string(peeringconditionsutils.GetStatus(fc, discoveryv1alpha1.OutgoingPeeringCondition))),
+		NetworkStatus:          types.StringValue(string(peeringconditionsutils.GetStatus(fc, discoveryv1alpha1.NetworkStatusCondition))),
+	}
+}
+
+type foreignClusterModel struct {
+	ClusterID              types.String `tfsdk:"cluster_id"`
+	Name                   types.String `tfsdk:"name"`
+	ClusterName            types.String `tfsdk:"cluster_name"`
+	AuthURL                types.String `tfsdk:"auth_url"`
+	PeeringType            types.String `tfsdk:"peering_type"`
+	OutgoingPeeringEnabled types.String `tfsdk:"outgoing_peering_enabled"`
+	IncomingPeeringEnabled types.String `tfsdk:"incoming_peering_enabled"`
+	AuthenticationStatus   types.String `tfsdk:"authentication_status"`
+	IncomingPeeringStatus  types.String `tfsdk:"incoming_peering_status"`
+	OutgoingPeeringStatus  types.String `tfsdk:"outgoing_peering_status"`
+	NetworkStatus          types.String `tfsdk:"network_status"`
+}
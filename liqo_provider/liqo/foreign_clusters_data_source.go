@@ -0,0 +1,84 @@
+package liqo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	discoveryv1alpha1 "github.com/liqotech/liqo/apis/discovery/v1alpha1"
+)
+
+var (
+	_ datasource.DataSource              = &foreignClustersDataSource{}
+	_ datasource.DataSourceWithConfigure = &foreignClustersDataSource{}
+)
+
+func NewForeignClustersDataSource() datasource.DataSource {
+	return &foreignClustersDataSource{}
+}
+
+type foreignClustersDataSource struct {
+	data *liqoProviderData
+}
+
+func (d *foreignClustersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_foreign_clusters"
+}
+
+func (d *foreignClustersDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "List all the ForeignClusters.",
+		Attributes: map[string]tfsdk.Attribute{
+			"foreign_clusters": {
+				Computed:    true,
+				Attributes:  tfsdk.ListNestedAttributes(foreignClusterAttributes(false)),
+				Description: "ForeignClusters known by the local cluster.",
+			},
+		},
+	}, nil
+}
+
+func (d *foreignClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	CRClient, _, err := d.data.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			err.Error(),
+		)
+		return
+	}
+
+	var foreignClusters discoveryv1alpha1.ForeignClusterList
+	if err := CRClient.List(ctx, &foreignClusters); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			err.Error(),
+		)
+		return
+	}
+
+	state := foreignClustersDataSourceModel{ForeignClusters: []foreignClusterModel{}}
+	for i := range foreignClusters.Items {
+		state.ForeignClusters = append(state.ForeignClusters, foreignClusterModelFrom(&foreignClusters.Items[i]))
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure method to obtain kubernetes Clients provided by provider
+func (d *foreignClustersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.data = req.ProviderData.(*liqoProviderData)
+}
+
+type foreignClustersDataSourceModel struct {
+	ForeignClusters []foreignClusterModel `tfsdk:"foreign_clusters"`
+}
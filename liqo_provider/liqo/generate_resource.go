@@ -1,10 +1,8 @@
 package liqo
 
 import (
-	"bytes"
 	"context"
-	"os"
-	"path/filepath"
+	"encoding/pem"
 	"terraform-provider-liqo/liqo/attribute_plan_modifier"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -12,21 +10,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/liqotech/liqo/pkg/auth"
+	liqoconsts "github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/utils"
 	foreigncluster "github.com/liqotech/liqo/pkg/utils/foreignCluster"
-	"github.com/mitchellh/go-homedir"
-	apimachineryschema "k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
-	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
-	"k8s.io/kubectl/pkg/scheme"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kubeTypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
-	_ resource.Resource              = &generateResource{}
-	_ resource.ResourceWithConfigure = &generateResource{}
+	_ resource.Resource                = &generateResource{}
+	_ resource.ResourceWithConfigure   = &generateResource{}
+	_ resource.ResourceWithImportState = &generateResource{}
 )
 
 func NewGenerateResource() resource.Resource {
@@ -34,9 +31,7 @@ func NewGenerateResource() resource.Resource {
 }
 
 type generateResource struct {
-	config     liqoProviderModel
-	CRClient   client.Client
-	KubeClient *kubernetes.Clientset
+	data *liqoProviderData
 }
 
 func (r *generateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -62,9 +57,15 @@ func (r *generateResource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diag
 				Computed:    true,
 				Description: "Provider authentication endpoint.",
 			},
+			"auth_ca": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "PEM-encoded CA certificate of the provider authentication service, read from the TLS Secret of its Ingress (null if it is not exposed through an Ingress with TLS).",
+			},
 			"local_token": {
 				Type:        types.StringType,
 				Computed:    true,
+				Sensitive:   true,
 				Description: "Provider authentication token.",
 			},
 			"liqo_namespace": {
@@ -90,129 +91,16 @@ func (r *generateResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	overrides := &clientcmd.ConfigOverrides{}
-	loader := &clientcmd.ClientConfigLoadingRules{}
-
-	configPaths := []string{}
-
-	if !r.config.KUBERNETES.KUBE_CONFIG_PATH.IsNull() {
-		configPaths = []string{r.config.KUBERNETES.KUBE_CONFIG_PATH.ValueString()}
-	} else if len(r.config.KUBERNETES.KUBE_CONFIG_PATHS) > 0 {
-		for _, configPath := range r.config.KUBERNETES.KUBE_CONFIG_PATHS {
-			configPaths = append(configPaths, configPath.ValueString())
-		}
-	} else if v := os.Getenv("KUBE_CONFIG_PATHS"); v != "" {
-		configPaths = filepath.SplitList(v)
-	}
-
-	if len(configPaths) > 0 {
-		expandedPaths := []string{}
-		for _, p := range configPaths {
-			path, err := homedir.Expand(p)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Unable to Create Resource",
-					err.Error(),
-				)
-				return
-			}
-			expandedPaths = append(expandedPaths, path)
-		}
-
-		if len(expandedPaths) == 1 {
-			loader.ExplicitPath = expandedPaths[0]
-		} else {
-			loader.Precedence = expandedPaths
-		}
-
-		ctxNotOk := r.config.KUBERNETES.KUBE_CTX.IsNull()
-		authInfoNotOk := r.config.KUBERNETES.KUBE_CTX_AUTH_INFO.IsNull()
-		clusterNotOk := r.config.KUBERNETES.KUBE_CTX_CLUSTER.IsNull()
-
-		if ctxNotOk || authInfoNotOk || clusterNotOk {
-			if ctxNotOk {
-				overrides.CurrentContext = r.config.KUBERNETES.KUBE_CTX.ValueString()
-			}
-
-			overrides.Context = clientcmdapi.Context{}
-			if authInfoNotOk {
-				overrides.Context.AuthInfo = r.config.KUBERNETES.KUBE_CTX_AUTH_INFO.ValueString()
-			}
-			if clusterNotOk {
-				overrides.Context.Cluster = r.config.KUBERNETES.KUBE_CTX_CLUSTER.ValueString()
-			}
-		}
-	}
-
-	if !r.config.KUBERNETES.KUBE_INSECURE.IsNull() {
-		overrides.ClusterInfo.InsecureSkipTLSVerify = !r.config.KUBERNETES.KUBE_INSECURE.ValueBool()
-	}
-	if !r.config.KUBERNETES.KUBE_CLUSTER_CA_CERT_DATA.IsNull() {
-		overrides.ClusterInfo.CertificateAuthorityData = bytes.NewBufferString(r.config.KUBERNETES.KUBE_CLUSTER_CA_CERT_DATA.ValueString()).Bytes()
-	}
-	if !r.config.KUBERNETES.KUBE_CLIENT_CERT_DATA.IsNull() {
-		overrides.AuthInfo.ClientCertificateData = bytes.NewBufferString(r.config.KUBERNETES.KUBE_CLIENT_CERT_DATA.ValueString()).Bytes()
-	}
-	if !r.config.KUBERNETES.KUBE_HOST.IsNull() {
-		hasCA := len(overrides.ClusterInfo.CertificateAuthorityData) != 0
-		hasCert := len(overrides.AuthInfo.ClientCertificateData) != 0
-		defaultTLS := hasCA || hasCert || overrides.ClusterInfo.InsecureSkipTLSVerify
-		host, _, err := rest.DefaultServerURL(r.config.KUBERNETES.KUBE_HOST.ValueString(), "", apimachineryschema.GroupVersion{}, defaultTLS)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Create Resource",
-				err.Error(),
-			)
-			return
-		}
-
-		overrides.ClusterInfo.Server = host.String()
-	}
-	if !r.config.KUBERNETES.KUBE_USER.IsNull() {
-		overrides.AuthInfo.Username = r.config.KUBERNETES.KUBE_USER.ValueString()
-	}
-	if !r.config.KUBERNETES.KUBE_PASSWORD.IsNull() {
-		overrides.AuthInfo.Password = r.config.KUBERNETES.KUBE_PASSWORD.ValueString()
-	}
-	if !r.config.KUBERNETES.KUBE_CLIENT_KEY_DATA.IsNull() {
-		overrides.AuthInfo.ClientKeyData = bytes.NewBufferString(r.config.KUBERNETES.KUBE_CLIENT_KEY_DATA.ValueString()).Bytes()
-	}
-	if !r.config.KUBERNETES.KUBE_TOKEN.IsNull() {
-		overrides.AuthInfo.Token = r.config.KUBERNETES.KUBE_TOKEN.ValueString()
-	}
-
-	if !r.config.KUBERNETES.KUBE_PROXY_URL.IsNull() {
-		overrides.ClusterDefaults.ProxyURL = r.config.KUBERNETES.KUBE_PROXY_URL.ValueString()
-	}
-
-	if len(r.config.KUBERNETES.KUBE_EXEC) > 0 {
-		exec := &clientcmdapi.ExecConfig{}
-		exec.InteractiveMode = clientcmdapi.IfAvailableExecInteractiveMode
-		exec.APIVersion = r.config.KUBERNETES.KUBE_EXEC[0].API_VERSION.ValueString()
-		exec.Command = r.config.KUBERNETES.KUBE_EXEC[0].COMMAND.ValueString()
-		for _, arg := range r.config.KUBERNETES.KUBE_EXEC[0].ARGS {
-			exec.Args = append(exec.Args, arg.ValueString())
-		}
-
-		for kk, vv := range r.config.KUBERNETES.KUBE_EXEC[0].ENV.Elements() {
-			exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: kk, Value: vv.String()})
-		}
-
-		overrides.AuthInfo.Exec = exec
-	}
-
-	clientCfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
-	if clientCfg == nil {
+	CRClient, _, err := r.data.Clients()
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Resource",
-			"Unable to Create Resource while creating clientCfg",
+			err.Error(),
 		)
 		return
 	}
 
-	var restCfg *rest.Config
-
-	restCfg, err := clientCfg.ClientConfig()
+	params, err := generatePeeringParameters(ctx, CRClient, plan.LiqoNamespace.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Resource",
@@ -221,111 +109,197 @@ func (r *generateResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	var CRClient client.Client
+	plan.ClusterID = types.StringValue(params.ClusterID)
+	plan.ClusterName = types.StringValue(params.ClusterName)
+	plan.LocalToken = types.StringValue(params.LocalToken)
+	plan.AuthEP = types.StringValue(params.AuthEP)
+	plan.AuthCA = authCAModel(params.AuthCA)
 
-	CRClient, err = client.New(restCfg, client.Options{Scheme: scheme.Scheme})
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			err.Error(),
-		)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+}
 
-	KubeClient, err := kubernetes.NewForConfig(restCfg)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			err.Error(),
-		)
+func (r *generateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state generateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	r.CRClient = CRClient
-	r.KubeClient = KubeClient
-
-	clusterIdentity, err := utils.GetClusterIdentityWithControllerClient(ctx, r.CRClient, plan.LiqoNamespace.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			err.Error(),
-		)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+}
 
-	localToken, err := auth.GetToken(ctx, r.CRClient, plan.LiqoNamespace.ValueString())
+func (r *generateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError(
+		"Unable to Update Resource",
+		"Update is not supported/permitted yet.",
+	)
+}
+
+func (r *generateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// ImportState retrieves again the peering parameters of the local cluster, the identifier is the Liqo namespace
+func (r *generateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	liqoNamespace := req.ID
+	if liqoNamespace == "" {
+		liqoNamespace = "liqo"
+	}
+
+	CRClient, _, err := r.data.Clients()
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
+			"Unable to Import Resource",
 			err.Error(),
 		)
 		return
 	}
 
-	authEP, err := foreigncluster.GetHomeAuthURL(ctx, r.CRClient, plan.LiqoNamespace.ValueString())
+	params, err := generatePeeringParameters(ctx, CRClient, liqoNamespace)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
+			"Unable to Import Resource",
 			err.Error(),
 		)
 		return
 	}
 
-	if clusterIdentity.ClusterName == "" {
-		clusterIdentity.ClusterName = clusterIdentity.ClusterID
+	state := generateResourceModel{
+		ClusterID:     types.StringValue(params.ClusterID),
+		ClusterName:   types.StringValue(params.ClusterName),
+		AuthEP:        types.StringValue(params.AuthEP),
+		AuthCA:        authCAModel(params.AuthCA),
+		LocalToken:    types.StringValue(params.LocalToken),
+		LiqoNamespace: types.StringValue(liqoNamespace),
 	}
 
-	plan.ClusterID = types.StringValue(clusterIdentity.ClusterID)
-	plan.ClusterName = types.StringValue(clusterIdentity.ClusterName)
-	plan.LocalToken = types.StringValue(localToken)
-	plan.AuthEP = types.StringValue(authEP)
-
-	diags = resp.State.Set(ctx, plan)
+	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
-func (r *generateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state generateResourceModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+// Configure method to obtain kubernetes Clients provided by provider
+func (r *generateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
 		return
 	}
 
-	diags = resp.State.Set(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	r.data = req.ProviderData.(*liqoProviderData)
 }
 
-func (r *generateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Unable to Update Resource",
-		"Update is not supported/permitted yet.",
-	)
+// peeringParameters are the parameters that remote clusters need to peer with the local one
+type peeringParameters struct {
+	ClusterID   string
+	ClusterName string
+	AuthEP      string
+	AuthCA      string
+	LocalToken  string
 }
 
-func (r *generateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+// generatePeeringParameters retrieves the cluster identity, the authentication token, the authentication endpoint
+// and the CA of the authentication service of the local cluster
+func generatePeeringParameters(ctx context.Context, CRClient client.Client, liqoNamespace string) (*peeringParameters, error) {
+	clusterIdentity, err := utils.GetClusterIdentityWithControllerClient(ctx, CRClient, liqoNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	localToken, err := auth.GetToken(ctx, CRClient, liqoNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	authEP, err := foreigncluster.GetHomeAuthURL(ctx, CRClient, liqoNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	authCA, err := getAuthCA(ctx, CRClient, liqoNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if clusterIdentity.ClusterName == "" {
+		clusterIdentity.ClusterName = clusterIdentity.ClusterID
+	}
+
+	return &peeringParameters{
+		ClusterID:   clusterIdentity.ClusterID,
+		ClusterName: clusterIdentity.ClusterName,
+		AuthEP:      authEP,
+		AuthCA:      authCA,
+		LocalToken:  localToken,
+	}, nil
 }
 
-// Configure method to obtain kubernetes Clients provided by provider
-func (r *generateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
-		return
+// getAuthCA returns the CA of the certificate exposed by the Ingress of the authentication service, taken from the ca.crt key
+// of its TLS Secret or, if missing, from the last certificate of the chain. It is empty when there is no Ingress with TLS,
+// as the certificate the authentication service generates on its own is self-signed and not stored anywhere
+func getAuthCA(ctx context.Context, CRClient client.Client, liqoNamespace string) (string, error) {
+	var ingress networkingv1.Ingress
+	err := CRClient.Get(ctx, kubeTypes.NamespacedName{Name: liqoconsts.AuthServiceName, Namespace: liqoNamespace}, &ingress)
+	if kerrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
 	}
 
-	r.config = req.ProviderData.(liqoProviderModel)
+	for _, ingressTLS := range ingress.Spec.TLS {
+		if ingressTLS.SecretName == "" {
+			continue
+		}
+
+		var secret corev1.Secret
+		err := CRClient.Get(ctx, kubeTypes.NamespacedName{Name: ingressTLS.SecretName, Namespace: liqoNamespace}, &secret)
+		if kerrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+
+		if ca := secret.Data["ca.crt"]; len(ca) > 0 {
+			return string(ca), nil
+		}
 
+		var last *pem.Block
+		for rest := secret.Data[corev1.TLSCertKey]; ; {
+			var block *pem.Block
+			if block, rest = pem.Decode(rest); block == nil {
+				break
+			}
+			last = block
+		}
+		if last != nil {
+			return string(pem.EncodeToMemory(last)), nil
+		}
+	}
+
+	return "", nil
+}
+
+// authCAModel converts the CA of the authentication service into its terraform value, null when it is unknown
+func authCAModel(authCA string) types.String {
+	if authCA == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(authCA)
 }
 
 type generateResourceModel struct {
 	ClusterID     types.String `tfsdk:"cluster_id"`
 	ClusterName   types.String `tfsdk:"cluster_name"`
 	AuthEP        types.String `tfsdk:"auth_ep"`
+	AuthCA        types.String `tfsdk:"auth_ca"`
 	LocalToken    types.String `tfsdk:"local_token"`
 	LiqoNamespace types.String `tfsdk:"liqo_namespace"`
 }
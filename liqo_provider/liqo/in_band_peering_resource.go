@@ -0,0 +1,446 @@
+package liqo
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-liqo/liqo/attribute_plan_modifier"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	discoveryv1alpha1 "github.com/liqotech/liqo/apis/discovery/v1alpha1"
+	"github.com/liqotech/liqo/pkg/liqoctl/peerib"
+	"github.com/liqotech/liqo/pkg/liqoctl/unpeerib"
+	"github.com/liqotech/liqo/pkg/utils"
+	foreigncluster "github.com/liqotech/liqo/pkg/utils/foreignCluster"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+var (
+	_ resource.Resource               = &inBandPeeringResource{}
+	_ resource.ResourceWithModifyPlan = &inBandPeeringResource{}
+)
+
+func NewInBandPeeringResource() resource.Resource {
+	return &inBandPeeringResource{}
+}
+
+// inBandPeeringResource connects to both clusters on its own, hence it does not use the provider kubernetes Clients
+type inBandPeeringResource struct {
+}
+
+func (p *inBandPeeringResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_in_band_peering"
+}
+
+func (p *inBandPeeringResource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "Execute an in-band peering, establishing the network tunnel before the authentication so that the authentication service does not need to be publicly exposed.",
+		Attributes: map[string]tfsdk.Attribute{
+			"local_cluster": {
+				Required:    true,
+				Attributes:  tfsdk.SingleNestedAttributes(kubernetesAttributes()),
+				Description: "Connection to the local cluster, which consumes the resources of the remote one.",
+			},
+			"local_liqo_namespace": {
+				Type:     types.StringType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.DefaultValue(types.StringValue("liqo")),
+					resource.RequiresReplace(),
+				},
+				Computed:    true,
+				Description: "Namespace where is Liqo installed in the local cluster.",
+			},
+			"remote_cluster": {
+				Required:    true,
+				Attributes:  tfsdk.SingleNestedAttributes(kubernetesAttributes()),
+				Description: "Connection to the remote cluster.",
+			},
+			"remote_liqo_namespace": {
+				Type:     types.StringType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.DefaultValue(types.StringValue("liqo")),
+					resource.RequiresReplace(),
+				},
+				Computed:    true,
+				Description: "Namespace where is Liqo installed in the remote cluster.",
+			},
+			"bidirectional": {
+				Type:     types.BoolType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.DefaultValue(types.BoolValue(false)),
+					resource.RequiresReplace(),
+				},
+				Computed:    true,
+				Description: "Enable the outgoing peering also from the remote cluster towards the local one.",
+			},
+			"timeouts": timeoutsAttribute(),
+			"local_cluster_id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Local cluster ID.",
+			},
+			"local_cluster_name": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Local cluster name.",
+			},
+			"remote_cluster_id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Remote cluster ID.",
+			},
+			"remote_cluster_name": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Remote cluster name.",
+			},
+		},
+	}, nil
+}
+
+// ModifyPlan plans the replacement of the resource when the updated connections reach different clusters than the peered ones,
+// the check is skipped when the clusters are not reachable yet
+func (p *inBandPeeringResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	var plan inBandPeeringResourceModel
+	if diags := req.Plan.Get(ctx, &plan); diags.HasError() {
+		return
+	}
+
+	var state inBandPeeringResourceModel
+	if diags := req.State.Get(ctx, &state); diags.HasError() {
+		return
+	}
+
+	local, remote, err := plan.connect()
+	if err != nil {
+		return
+	}
+	if err := plan.setClusterIdentities(ctx, local, remote); err != nil {
+		return
+	}
+
+	if !plan.LocalClusterID.Equal(state.LocalClusterID) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("local_cluster"))
+	}
+	if !plan.RemoteClusterID.Equal(state.RemoteClusterID) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("remote_cluster"))
+	}
+
+	diags := resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// This resource will reproduce the same effect of "liqoctl peer in-band" command:
+// the network configurations are exchanged and the authentication and proxy services are remapped through the tunnel,
+// then the ForeignClusters are created and the peering is waited for
+func (p *inBandPeeringResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan inBandPeeringResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	local, remote, err := plan.connect()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := plan.setClusterIdentities(ctx, local, remote); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	localFactory, err := local.Factory(plan.LocalLiqoNamespace.ValueString(), false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	remoteFactory, err := remote.Factory(plan.RemoteLiqoNamespace.ValueString(), true)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	options := peerib.Options{
+		LocalFactory:  localFactory,
+		RemoteFactory: remoteFactory,
+		Bidirectional: plan.Bidirectional.ValueBool(),
+		Timeout:       plan.Timeouts.CreateTimeout(),
+	}
+	if err := options.Run(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read checks that the ForeignClusters created by the in-band peering still exist,
+// the resource is removed from the state otherwise
+func (p *inBandPeeringResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state inBandPeeringResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	local, remote, err := state.connect()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	sides := []struct {
+		data            *liqoProviderData
+		remoteClusterID string
+		outgoing        bool
+	}{
+		{local, state.RemoteClusterID.ValueString(), true},
+		{remote, state.LocalClusterID.ValueString(), state.Bidirectional.ValueBool()},
+	}
+	for _, side := range sides {
+		CRClient, _, err := side.data.Clients()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Resource",
+				err.Error(),
+			)
+			return
+		}
+
+		fc, err := foreigncluster.GetForeignClusterByID(ctx, CRClient, side.remoteClusterID)
+		if kerrors.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		} else if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Resource",
+				err.Error(),
+			)
+			return
+		}
+
+		if fc.Spec.PeeringType != discoveryv1alpha1.PeeringTypeInBand ||
+			(side.outgoing && fc.Spec.OutgoingPeeringEnabled == discoveryv1alpha1.PeeringEnabledNo) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update of In-Band Peering Resource only concerns the connections to the clusters and the timeouts,
+// the clusters reached through the updated connections must be the same as before
+func (p *inBandPeeringResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan inBandPeeringResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state inBandPeeringResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	local, remote, err := plan.connect()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := plan.setClusterIdentities(ctx, local, remote); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	if !plan.LocalClusterID.Equal(state.LocalClusterID) || !plan.RemoteClusterID.Equal(state.RemoteClusterID) {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			"The updated connections reach different clusters than the peered ones, the resource must be replaced.",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete of In-Band Peering Resource reproduces "liqoctl unpeer in-band": the peering is disabled on both clusters and,
+// once it has been torn down, the remapped IPs, the ForeignClusters and the tenant namespaces are removed
+func (p *inBandPeeringResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state inBandPeeringResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	local, remote, err := state.connect()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	localFactory, err := local.Factory(state.LocalLiqoNamespace.ValueString(), false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	remoteFactory, err := remote.Factory(state.RemoteLiqoNamespace.ValueString(), true)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	options := unpeerib.Options{
+		LocalFactory:        localFactory,
+		RemoteFactory:       remoteFactory,
+		LocalLiqoNamespace:  state.LocalLiqoNamespace.ValueString(),
+		RemoteLiqoNamespace: state.RemoteLiqoNamespace.ValueString(),
+		Timeout:             state.Timeouts.DeleteTimeout(),
+	}
+	if err := options.Run(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// connect prepares the kubernetes Clients of the local and of the remote cluster
+func (m *inBandPeeringResourceModel) connect() (local, remote *liqoProviderData, err error) {
+	local, err = newLiqoProviderData(liqoProviderModel{KUBERNETES: m.LocalCluster})
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid connection to the local cluster: %w", err)
+	}
+
+	remote, err = newLiqoProviderData(liqoProviderModel{KUBERNETES: m.RemoteCluster})
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid connection to the remote cluster: %w", err)
+	}
+	return local, remote, nil
+}
+
+// setClusterIdentities retrieves the identities of both clusters and copies them into the model
+func (m *inBandPeeringResourceModel) setClusterIdentities(ctx context.Context, local, remote *liqoProviderData) error {
+	identities := []*discoveryv1alpha1.ClusterIdentity{}
+	for _, side := range []struct {
+		data          *liqoProviderData
+		liqoNamespace string
+	}{{local, m.LocalLiqoNamespace.ValueString()}, {remote, m.RemoteLiqoNamespace.ValueString()}} {
+		CRClient, _, err := side.data.Clients()
+		if err != nil {
+			return err
+		}
+
+		identity, err := utils.GetClusterIdentityWithControllerClient(ctx, CRClient, side.liqoNamespace)
+		if err != nil {
+			return err
+		}
+		if identity.ClusterName == "" {
+			identity.ClusterName = identity.ClusterID
+		}
+		identities = append(identities, &identity)
+	}
+
+	m.LocalClusterID = types.StringValue(identities[0].ClusterID)
+	m.LocalClusterName = types.StringValue(identities[0].ClusterName)
+	m.RemoteClusterID = types.StringValue(identities[1].ClusterID)
+	m.RemoteClusterName = types.StringValue(identities[1].ClusterName)
+
+	if identities[0].ClusterID == identities[1].ClusterID {
+		return fmt.Errorf("both connections reach the same cluster %q", identities[0].ClusterID)
+	}
+	return nil
+}
+
+type inBandPeeringResourceModel struct {
+	LocalCluster        *kube_conf   `tfsdk:"local_cluster"`
+	LocalLiqoNamespace  types.String `tfsdk:"local_liqo_namespace"`
+	RemoteCluster       *kube_conf   `tfsdk:"remote_cluster"`
+	RemoteLiqoNamespace types.String `tfsdk:"remote_liqo_namespace"`
+	Bidirectional       types.Bool   `tfsdk:"bidirectional"`
+	Timeouts            *timeouts    `tfsdk:"timeouts"`
+
+	LocalClusterID    types.String `tfsdk:"local_cluster_id"`
+	LocalClusterName  types.String `tfsdk:"local_cluster_name"`
+	RemoteClusterID   types.String `tfsdk:"remote_cluster_id"`
+	RemoteClusterName types.String `tfsdk:"remote_cluster_name"`
+}
@@ -0,0 +1,448 @@
+package liqo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"terraform-provider-liqo/liqo/attribute_plan_modifier"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqoctl/factory"
+	"github.com/liqotech/liqo/pkg/liqoctl/install"
+	"github.com/liqotech/liqo/pkg/liqoctl/install/generic"
+	"github.com/liqotech/liqo/pkg/liqoctl/uninstall"
+	"github.com/liqotech/liqo/pkg/utils"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	_ resource.Resource              = &installResource{}
+	_ resource.ResourceWithConfigure = &installResource{}
+)
+
+func NewInstallResource() resource.Resource {
+	return &installResource{}
+}
+
+type installResource struct {
+	data *liqoProviderData
+}
+
+func (i *installResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_install"
+}
+
+func (i *installResource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "Install or upgrade Liqo through its Helm chart.",
+		Attributes: map[string]tfsdk.Attribute{
+			"liqo_namespace": {
+				Type:     types.StringType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.DefaultValue(types.StringValue("liqo")),
+					resource.RequiresReplace(),
+				},
+				Computed:    true,
+				Description: "Namespace where to install Liqo.",
+			},
+			"chart_version": {
+				Type:     types.StringType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Version of Liqo to be installed, among releases and commit SHAs. Defaults to the latest stable release.",
+			},
+			"cluster_name": {
+				Type:     types.StringType,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Name identifying the cluster in Liqo. A random name is generated if not set.",
+			},
+			"cluster_id_override": {
+				Type:     types.StringType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
+				Description: "UUID to be used as cluster ID, instead of a randomly generated one.",
+			},
+			"cluster_labels": {
+				Type:        types.MapType{ElemType: types.StringType},
+				Optional:    true,
+				Description: "Labels identifying the cluster, propagated to the virtual nodes.",
+			},
+			"pod_cidr": {
+				Type:     types.StringType,
+				Required: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
+				Description: "Pod CIDR of the cluster, it cannot be changed once Liqo is installed.",
+			},
+			"service_cidr": {
+				Type:     types.StringType,
+				Required: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
+				Description: "Service CIDR of the cluster, it cannot be changed once Liqo is installed.",
+			},
+			"reserved_subnets": {
+				Type:        types.ListType{ElemType: types.StringType},
+				Optional:    true,
+				Description: "Private CIDRs to be excluded, as already in use (e.g. the subnet of the cluster nodes).",
+			},
+			"api_server_url": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "Kubernetes API Server URL advertised to the remote clusters. The chart default is kept if not set.",
+			},
+			"auth_service_type": {
+				Type:     types.StringType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.DefaultValue(types.StringValue("LoadBalancer")),
+				},
+				Computed: true,
+				Validators: []tfsdk.AttributeValidator{
+					stringvalidator.OneOf("LoadBalancer", "NodePort", "ClusterIP"),
+				},
+				Description: "Type of the service exposing the authentication service (LoadBalancer, NodePort or ClusterIP).",
+			},
+			"gateway_service_type": {
+				Type:     types.StringType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.DefaultValue(types.StringValue("LoadBalancer")),
+				},
+				Computed: true,
+				Validators: []tfsdk.AttributeValidator{
+					stringvalidator.OneOf("LoadBalancer", "NodePort", "ClusterIP"),
+				},
+				Description: "Type of the service exposing the network gateway (LoadBalancer, NodePort or ClusterIP).",
+			},
+			"set": {
+				Type:        types.MapType{ElemType: types.StringType},
+				Optional:    true,
+				Description: "Additional chart values, in the same format of \"helm install --set\" (e.g. \"controllerManager.replicas\" = \"2\").",
+			},
+			"timeouts": timeoutsAttribute(),
+			"cluster_id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "ID of the cluster, to be referenced by the peering resources.",
+			},
+		},
+	}, nil
+}
+
+// This resource will reproduce the same effect of "liqoctl install" command:
+// the chart is installed with Helm, then the authentication service and the controller manager are waited for
+func (i *installResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan installResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := i.installOrUpgrade(ctx, &plan, plan.Timeouts.CreateTimeout()); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read checks that the Liqo release still exists and refreshes the cluster identity
+func (i *installResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state installResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	f, err := i.factory(state.LiqoNamespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	release, err := f.HelmClient().GetRelease(install.LiqoReleaseName)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			err.Error(),
+		)
+		return
+	}
+	if release.Chart != nil && release.Chart.Metadata != nil {
+		state.ChartVersion = types.StringValue(release.Chart.Metadata.Version)
+	}
+
+	clusterIdentity, err := utils.GetClusterIdentityWithControllerClient(ctx, f.CRClient, state.LiqoNamespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			err.Error(),
+		)
+		return
+	}
+	state.ClusterID = types.StringValue(clusterIdentity.ClusterID)
+	state.ClusterName = types.StringValue(clusterIdentity.ClusterName)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update of Install Resource upgrades the Liqo release with the new parameters
+func (i *installResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan installResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := i.installOrUpgrade(ctx, &plan, plan.Timeouts.UpdateTimeout()); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete of Install Resource reproduces "liqoctl uninstall": the uninstallation fails while peerings are still active
+func (i *installResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state installResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	f, err := i.factory(state.LiqoNamespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, state.Timeouts.DeleteTimeout())
+	defer cancel()
+
+	options := uninstall.Options{Factory: f}
+	if err := options.Run(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// Configure method to obtain kubernetes Clients provided by provider
+func (i *installResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	i.data = req.ProviderData.(*liqoProviderData)
+}
+
+// factory returns the liqoctl Factory of the cluster, with its Helm client already initialized:
+// liqoctl would otherwise initialize it lazily and exit the process on failure
+func (i *installResource) factory(liqoNamespace string) (*factory.Factory, error) {
+	f, err := i.data.Factory(liqoNamespace, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.HelmClientOrError(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// installOrUpgrade installs or upgrades the Liqo chart as "liqoctl install" does,
+// then waits for Liqo to be ready and copies the resulting cluster identity into the model.
+// The timeout bounds the whole operation, hence the Helm release and the wait share the same deadline
+func (i *installResource) installOrUpgrade(ctx context.Context, m *installResourceModel, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	f, err := i.factory(m.LiqoNamespace.ValueString())
+	if err != nil {
+		return err
+	}
+
+	options := &install.Options{
+		Factory:           f,
+		CommandName:       "liqoctl",
+		Version:           m.ChartVersion.ValueString(),
+		RepoURL:           "https://github.com/liqotech/liqo",
+		Timeout:           time.Until(deadline),
+		ClusterName:       m.ClusterName.ValueString(),
+		ClusterLabels:     map[string]string{},
+		SharingPercentage: 90,
+		PodCIDR:           m.PodCIDR.ValueString(),
+		ServiceCIDR:       m.ServiceCIDR.ValueString(),
+		ReservedSubnets:   []string{},
+		APIServer:         m.APIServerURL.ValueString(),
+		OverrideValues: []string{
+			fmt.Sprintf("auth.service.type=%s", m.AuthServiceType.ValueString()),
+			fmt.Sprintf("gateway.service.type=%s", m.GatewayServiceType.ValueString()),
+		},
+	}
+
+	// The API server URL is advertised only if explicitly set, as the kubeconfig one often points to localhost (e.g. kind)
+	if options.APIServer == "" {
+		options.DisableAPIServerDefaulting = true
+	} else {
+		options.DisableAPIServerSanityChecks = true
+	}
+
+	for key, value := range m.ClusterLabels {
+		options.ClusterLabels[key] = value.ValueString()
+	}
+	for _, subnet := range m.ReservedSubnets {
+		options.ReservedSubnets = append(options.ReservedSubnets, subnet.ValueString())
+	}
+	if m.ClusterIDOverride.ValueString() != "" {
+		options.OverrideValues = append(options.OverrideValues,
+			fmt.Sprintf("discovery.config.clusterIDOverride=%s", m.ClusterIDOverride.ValueString()))
+	}
+
+	keys := make([]string, 0, len(m.Set))
+	for key := range m.Set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		options.OverrideValues = append(options.OverrideValues, fmt.Sprintf("%s=%s", key, m.Set[key].ValueString()))
+	}
+
+	if err := options.Run(ctx, generic.New(options)); err != nil {
+		return err
+	}
+
+	if err := waitForLiqoDeployments(ctx, f.CRClient, m.LiqoNamespace.ValueString(), timeout); err != nil {
+		return err
+	}
+
+	clusterIdentity, err := utils.GetClusterIdentityWithControllerClient(ctx, f.CRClient, m.LiqoNamespace.ValueString())
+	if err != nil {
+		return err
+	}
+
+	m.ChartVersion = types.StringValue(options.Version)
+	m.ClusterID = types.StringValue(clusterIdentity.ClusterID)
+	m.ClusterName = types.StringValue(clusterIdentity.ClusterName)
+	return nil
+}
+
+// waitForLiqoDeployments waits until the authentication service and the controller manager deployments are available,
+// or the deadline of the context expires. The timeout the deadline derives from is only reported in the error
+func waitForLiqoDeployments(ctx context.Context, CRClient client.Client, liqoNamespace string, timeout time.Duration) error {
+	pending := ""
+
+	err := wait.PollImmediateUntilWithContext(ctx, time.Second, func(ctx context.Context) (bool, error) {
+		for _, name := range []string{consts.AuthAppName, consts.ControllerManagerAppName} {
+			var deployments appsv1.DeploymentList
+			if err := CRClient.List(ctx, &deployments, client.InNamespace(liqoNamespace),
+				client.MatchingLabels{consts.K8sAppNameKey: name}); err != nil {
+				return false, err
+			}
+			if len(deployments.Items) == 0 {
+				pending = fmt.Sprintf("the %s deployment has not been created yet", name)
+				return false, nil
+			}
+
+			for i := range deployments.Items {
+				deployment := &deployments.Items[i]
+				replicas := int32(1)
+				if deployment.Spec.Replicas != nil {
+					replicas = *deployment.Spec.Replicas
+				}
+
+				if deployment.Status.ObservedGeneration < deployment.Generation ||
+					deployment.Status.UpdatedReplicas < replicas || deployment.Status.AvailableReplicas < replicas {
+					pending = fmt.Sprintf("the deployment %q has %d available replicas out of %d",
+						deployment.Name, deployment.Status.AvailableReplicas, replicas)
+					return false, nil
+				}
+			}
+		}
+
+		return true, nil
+	})
+
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return fmt.Errorf("timed out after %s waiting for Liqo to be ready, %s", timeout, pending)
+	}
+	return err
+}
+
+type installResourceModel struct {
+	LiqoNamespace      types.String            `tfsdk:"liqo_namespace"`
+	ChartVersion       types.String            `tfsdk:"chart_version"`
+	ClusterName        types.String            `tfsdk:"cluster_name"`
+	ClusterIDOverride  types.String            `tfsdk:"cluster_id_override"`
+	ClusterLabels      map[string]types.String `tfsdk:"cluster_labels"`
+	PodCIDR            types.String            `tfsdk:"pod_cidr"`
+	ServiceCIDR        types.String            `tfsdk:"service_cidr"`
+	ReservedSubnets    []types.String          `tfsdk:"reserved_subnets"`
+	APIServerURL       types.String            `tfsdk:"api_server_url"`
+	AuthServiceType    types.String            `tfsdk:"auth_service_type"`
+	GatewayServiceType types.String            `tfsdk:"gateway_service_type"`
+	Set                map[string]types.String `tfsdk:"set"`
+	Timeouts           *timeouts               `tfsdk:"timeouts"`
+
+	ClusterID types.String `tfsdk:"cluster_id"`
+}
@@ -0,0 +1,428 @@
+package liqo
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	netv1alpha1 "github.com/liqotech/liqo/apis/net/v1alpha1"
+	"github.com/liqotech/liqo/pkg/consts"
+	foreigncluster "github.com/liqotech/liqo/pkg/utils/foreignCluster"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	_ datasource.DataSource                     = &networkCheckDataSource{}
+	_ datasource.DataSourceWithConfigure        = &networkCheckDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &networkCheckDataSource{}
+)
+
+func NewNetworkCheckDataSource() datasource.DataSource {
+	return &networkCheckDataSource{}
+}
+
+type networkCheckDataSource struct {
+	data *liqoProviderData
+}
+
+func (d *networkCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_check"
+}
+
+func (d *networkCheckDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "Compare the network configuration of the local cluster with the one of a remote cluster, reporting the overlapping CIDRs and how Liqo remapped them.",
+		Attributes: map[string]tfsdk.Attribute{
+			"cluster_id": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "ID of the remote cluster, used to read the network configurations exchanged during the peering.",
+			},
+			"remote_cluster": {
+				Optional:    true,
+				Attributes:  tfsdk.SingleNestedAttributes(kubernetesAttributes()),
+				Description: "Connection to the remote cluster, used to read its network configuration before the peering.",
+			},
+			"require_processed": {
+				Type:        types.BoolType,
+				Optional:    true,
+				Description: "Fail when the network configurations exchanged with the remote cluster have not been processed, i.e. the CIDRs have not been remapped yet, instead of emitting a warning.",
+			},
+			"local_network": {
+				Computed:    true,
+				Attributes:  tfsdk.SingleNestedAttributes(networkAttributes()),
+				Description: "Network configuration of the local cluster.",
+			},
+			"remote_network": {
+				Computed:    true,
+				Attributes:  tfsdk.SingleNestedAttributes(networkAttributes()),
+				Description: "Network configuration of the remote cluster.",
+			},
+			"overlapping_cidrs": {
+				Type:        types.ListType{ElemType: types.StringType},
+				Computed:    true,
+				Description: "Remote CIDRs overlapping the local ones, which Liqo has to remap.",
+			},
+			"processed": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether the network configurations exchanged with the remote cluster have been processed by both clusters.",
+			},
+			"remote_pod_cidr_remapped": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "CIDR through which the local cluster reaches the remote pods (\"None\" if not remapped).",
+			},
+			"remote_external_cidr_remapped": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "CIDR through which the local cluster reaches the remote external CIDR (\"None\" if not remapped).",
+			},
+			"local_pod_cidr_remapped": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "CIDR through which the remote cluster reaches the local pods (\"None\" if not remapped).",
+			},
+			"local_external_cidr_remapped": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "CIDR through which the remote cluster reaches the local external CIDR (\"None\" if not remapped).",
+			},
+		},
+	}, nil
+}
+
+func (d *networkCheckDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("cluster_id"),
+			path.MatchRoot("remote_cluster"),
+		),
+	}
+}
+
+func (d *networkCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config networkCheckDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	CRClient, _, err := d.data.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			err.Error(),
+		)
+		return
+	}
+
+	state := networkCheckDataSourceModel{
+		ClusterID:        config.ClusterID,
+		RemoteCluster:    config.RemoteCluster,
+		RequireProcessed: config.RequireProcessed,
+		OverlappingCIDRs: []types.String{},
+		Processed:        types.BoolValue(false),
+
+		RemotePodCIDRRemapped:      types.StringNull(),
+		RemoteExternalCIDRRemapped: types.StringNull(),
+		LocalPodCIDRRemapped:       types.StringNull(),
+		LocalExternalCIDRRemapped:  types.StringNull(),
+	}
+
+	if state.LocalNetwork, err = getNetwork(ctx, CRClient); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			fmt.Sprintf("unable to retrieve the local network configuration: %s", err),
+		)
+		return
+	}
+
+	if config.RemoteCluster != nil {
+		remote, err := newLiqoProviderData(liqoProviderModel{KUBERNETES: config.RemoteCluster})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Data Source",
+				fmt.Sprintf("invalid connection to the remote cluster: %s", err),
+			)
+			return
+		}
+
+		remoteCRClient, _, err := remote.Clients()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Data Source",
+				err.Error(),
+			)
+			return
+		}
+
+		if state.RemoteNetwork, err = getNetwork(ctx, remoteCRClient); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Data Source",
+				fmt.Sprintf("unable to retrieve the remote network configuration: %s", err),
+			)
+			return
+		}
+	}
+
+	if !config.ClusterID.IsNull() {
+		resp.Diagnostics.Append(state.readNetworkConfigs(ctx, CRClient)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if state.RemoteNetwork != nil {
+		overlaps, err := overlappingCIDRs(state.LocalNetwork, state.RemoteNetwork)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Data Source",
+				err.Error(),
+			)
+			return
+		}
+
+		for _, overlap := range overlaps {
+			state.OverlappingCIDRs = append(state.OverlappingCIDRs, types.StringValue(overlap))
+		}
+		if len(overlaps) > 0 && !state.Processed.ValueBool() {
+			resp.Diagnostics.AddWarning(
+				"Overlapping CIDRs",
+				fmt.Sprintf("The remote cluster network overlaps the local one, hence Liqo has to remap it:\n  %s",
+					strings.Join(overlaps, "\n  ")),
+			)
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure method to obtain kubernetes Clients provided by provider
+func (d *networkCheckDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.data = req.ProviderData.(*liqoProviderData)
+}
+
+// readNetworkConfigs reads the NetworkConfigs exchanged with the remote cluster: the local one, whose status reports
+// how the remote cluster remapped the local CIDRs, and the remote one, whose status reports how the local cluster remapped the remote CIDRs.
+// NetworkConfigs which are not processed are reported as a warning, or as an error when require_processed is set,
+// as Liqo does not process them until it finds a free network to remap the CIDRs to
+func (m *networkCheckDataSourceModel) readNetworkConfigs(ctx context.Context, CRClient client.Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+	clusterID := m.ClusterID.ValueString()
+
+	notProcessed := func(reason string) {
+		summary, detail := "Network Configuration Not Processed", fmt.Sprintf("The network configuration exchanged with the cluster %q %s.", clusterID, reason)
+		if m.RequireProcessed.ValueBool() {
+			diags.AddError(summary, detail)
+		} else {
+			diags.AddWarning(summary, detail)
+		}
+	}
+
+	fc, err := foreigncluster.GetForeignClusterByID(ctx, CRClient, clusterID)
+	if kerrors.IsNotFound(err) {
+		notProcessed("does not exist yet, as there is no ForeignCluster for it")
+		return diags
+	} else if err != nil {
+		diags.AddError("Unable to Read Data Source", err.Error())
+		return diags
+	}
+
+	namespace := fc.Status.TenantNamespace.Local
+	if namespace == "" {
+		notProcessed("does not exist yet, as the tenant namespace has not been created")
+		return diags
+	}
+
+	local, err := getters.GetNetworkConfigByLabel(ctx, CRClient, namespace, liqolabels.LocalLabelSelectorForCluster(clusterID))
+	if client.IgnoreNotFound(err) != nil {
+		diags.AddError("Unable to Read Data Source", err.Error())
+		return diags
+	}
+
+	remote, err := getters.GetNetworkConfigByLabel(ctx, CRClient, namespace, liqolabels.RemoteLabelSelectorForCluster(clusterID))
+	if client.IgnoreNotFound(err) != nil {
+		diags.AddError("Unable to Read Data Source", err.Error())
+		return diags
+	}
+
+	if remote != nil && m.RemoteNetwork == nil {
+		m.RemoteNetwork = &networkModel{
+			PodCIDR:         types.StringValue(remote.Spec.PodCIDR),
+			ServiceCIDR:     types.StringNull(),
+			ExternalCIDR:    types.StringValue(remote.Spec.ExternalCIDR),
+			ReservedSubnets: []types.String{},
+		}
+	}
+
+	switch {
+	case local == nil || remote == nil:
+		notProcessed("has not been exchanged yet")
+	case !remote.Status.Processed:
+		notProcessed(fmt.Sprintf("has not been processed by the local cluster, the remote pod CIDR %s and external CIDR %s could not be remapped yet",
+			remote.Spec.PodCIDR, remote.Spec.ExternalCIDR))
+	case !local.Status.Processed:
+		notProcessed(fmt.Sprintf("has not been processed by the remote cluster, the local pod CIDR %s and external CIDR %s could not be remapped yet",
+			local.Spec.PodCIDR, local.Spec.ExternalCIDR))
+	default:
+		m.Processed = types.BoolValue(true)
+	}
+
+	if remote != nil && remote.Status.Processed {
+		m.RemotePodCIDRRemapped = types.StringValue(remote.Status.PodCIDRNAT)
+		m.RemoteExternalCIDRRemapped = types.StringValue(remote.Status.ExternalCIDRNAT)
+	}
+	if local != nil && local.Status.Processed {
+		m.LocalPodCIDRRemapped = types.StringValue(local.Status.PodCIDRNAT)
+		m.LocalExternalCIDRRemapped = types.StringValue(local.Status.ExternalCIDRNAT)
+	}
+
+	return diags
+}
+
+// networkAttributes returns the attributes describing the network configuration of a cluster
+func networkAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"pod_cidr": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Pod CIDR of the cluster.",
+		},
+		"service_cidr": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "Service CIDR of the cluster, known only when reading the cluster directly.",
+		},
+		"external_cidr": {
+			Type:        types.StringType,
+			Computed:    true,
+			Description: "External CIDR of the cluster, used to reach its external endpoints.",
+		},
+		"reserved_subnets": {
+			Type:        types.ListType{ElemType: types.StringType},
+			Computed:    true,
+			Description: "Subnets excluded from the remapping, as already in use.",
+		},
+	}
+}
+
+// getNetwork reads the network configuration of a cluster from its IpamStorage
+func getNetwork(ctx context.Context, CRClient client.Client) (*networkModel, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&liqolabels.IPAMStorageLabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	ipamStorage, err := getters.GetIPAMStorageByLabel(ctx, CRClient, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return networkModelFrom(ipamStorage), nil
+}
+
+// networkModelFrom converts an IpamStorage into its data source representation
+func networkModelFrom(ipamStorage *netv1alpha1.IpamStorage) *networkModel {
+	network := &networkModel{
+		PodCIDR:         types.StringValue(ipamStorage.Spec.PodCIDR),
+		ServiceCIDR:     types.StringValue(ipamStorage.Spec.ServiceCIDR),
+		ExternalCIDR:    types.StringValue(ipamStorage.Spec.ExternalCIDR),
+		ReservedSubnets: []types.String{},
+	}
+	for _, subnet := range ipamStorage.Spec.ReservedSubnets {
+		network.ReservedSubnets = append(network.ReservedSubnets, types.StringValue(subnet))
+	}
+	return network
+}
+
+// overlappingCIDRs lists the remote pod and external CIDRs overlapping a network in use by the local cluster,
+// which are the ones Liqo has to remap
+func overlappingCIDRs(local, remote *networkModel) ([]string, error) {
+	localCIDRs := []struct{ name, cidr string }{
+		{"pod CIDR", local.PodCIDR.ValueString()},
+		{"service CIDR", local.ServiceCIDR.ValueString()},
+		{"external CIDR", local.ExternalCIDR.ValueString()},
+	}
+	for _, subnet := range local.ReservedSubnets {
+		localCIDRs = append(localCIDRs, struct{ name, cidr string }{"reserved subnet", subnet.ValueString()})
+	}
+
+	remoteCIDRs := []struct{ name, cidr string }{
+		{"pod CIDR", remote.PodCIDR.ValueString()},
+		{"external CIDR", remote.ExternalCIDR.ValueString()},
+	}
+
+	overlaps := []string{}
+	for _, r := range remoteCIDRs {
+		for _, l := range localCIDRs {
+			if r.cidr == "" || l.cidr == "" || r.cidr == consts.DefaultCIDRValue || l.cidr == consts.DefaultCIDRValue {
+				continue
+			}
+
+			overlap, err := cidrsOverlap(r.cidr, l.cidr)
+			if err != nil {
+				return nil, err
+			}
+			if overlap {
+				overlaps = append(overlaps, fmt.Sprintf("remote %s %s overlaps local %s %s", r.name, r.cidr, l.name, l.cidr))
+			}
+		}
+	}
+	return overlaps, nil
+}
+
+// cidrsOverlap returns whether two CIDRs share at least one address
+func cidrsOverlap(a, b string) (bool, error) {
+	_, netA, err := net.ParseCIDR(a)
+	if err != nil {
+		return false, err
+	}
+	_, netB, err := net.ParseCIDR(b)
+	if err != nil {
+		return false, err
+	}
+	return netA.Contains(netB.IP) || netB.Contains(netA.IP), nil
+}
+
+type networkModel struct {
+	PodCIDR         types.String   `tfsdk:"pod_cidr"`
+	ServiceCIDR     types.String   `tfsdk:"service_cidr"`
+	ExternalCIDR    types.String   `tfsdk:"external_cidr"`
+	ReservedSubnets []types.String `tfsdk:"reserved_subnets"`
+}
+
+type networkCheckDataSourceModel struct {
+	ClusterID        types.String `tfsdk:"cluster_id"`
+	RemoteCluster    *kube_conf   `tfsdk:"remote_cluster"`
+	RequireProcessed types.Bool   `tfsdk:"require_processed"`
+
+	LocalNetwork               *networkModel  `tfsdk:"local_network"`
+	RemoteNetwork              *networkModel  `tfsdk:"remote_network"`
+	OverlappingCIDRs           []types.String `tfsdk:"overlapping_cidrs"`
+	Processed                  types.Bool     `tfsdk:"processed"`
+	RemotePodCIDRRemapped      types.String   `tfsdk:"remote_pod_cidr_remapped"`
+	RemoteExternalCIDRRemapped types.String   `tfsdk:"remote_external_cidr_remapped"`
+	LocalPodCIDRRemapped       types.String   `tfsdk:"local_pod_cidr_remapped"`
+	LocalExternalCIDRRemapped  types.String   `tfsdk:"local_external_cidr_remapped"`
+}
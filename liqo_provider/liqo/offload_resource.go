@@ -1,33 +1,40 @@
 package liqo
 
 import (
-	"bytes"
 	"context"
-	"os"
-	"path/filepath"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"terraform-provider-liqo/liqo/attribute_plan_modifier"
+	"terraform-provider-liqo/liqo/resource_validator"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	offloadingv1alpha1 "github.com/liqotech/liqo/apis/offloading/v1alpha1"
 	"github.com/liqotech/liqo/pkg/consts"
-	"github.com/mitchellh/go-homedir"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	apimachineryschema "k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
-	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
-	"k8s.io/kubectl/pkg/scheme"
+	kubeTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8shelper "k8s.io/component-helpers/scheduling/corev1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 var (
-	_ resource.Resource              = &offloadResource{}
-	_ resource.ResourceWithConfigure = &offloadResource{}
+	_ resource.Resource                     = &offloadResource{}
+	_ resource.ResourceWithConfigure        = &offloadResource{}
+	_ resource.ResourceWithImportState      = &offloadResource{}
+	_ resource.ResourceWithConfigValidators = &offloadResource{}
+	_ resource.ResourceWithModifyPlan       = &offloadResource{}
 )
 
 func NewOffloadResource() resource.Resource {
@@ -35,7 +42,7 @@ func NewOffloadResource() resource.Resource {
 }
 
 type offloadResource struct {
-	config liqoProviderModel
+	data *liqoProviderData
 }
 
 func (o *offloadResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,8 +54,11 @@ func (o *offloadResource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagn
 		Description: "Offload a namespace.",
 		Attributes: map[string]tfsdk.Attribute{
 			"namespace": {
-				Type:        types.StringType,
-				Required:    true,
+				Type:     types.StringType,
+				Required: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
 				Description: "Offload a namespace.",
 			},
 			"pod_offloading_strategy": {
@@ -57,7 +67,14 @@ func (o *offloadResource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagn
 				PlanModifiers: []tfsdk.AttributePlanModifier{
 					attribute_plan_modifier.DefaultValue(types.StringValue("LocalAndRemote")),
 				},
-				Computed:    true,
+				Computed: true,
+				Validators: []tfsdk.AttributeValidator{
+					stringvalidator.OneOf(
+						string(offloadingv1alpha1.LocalAndRemotePodOffloadingStrategyType),
+						string(offloadingv1alpha1.LocalPodOffloadingStrategyType),
+						string(offloadingv1alpha1.RemotePodOffloadingStrategyType),
+					),
+				},
 				Description: "Namespace to offload.",
 			},
 			"namespace_mapping_strategy": {
@@ -66,41 +83,109 @@ func (o *offloadResource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagn
 				PlanModifiers: []tfsdk.AttributePlanModifier{
 					attribute_plan_modifier.DefaultValue(types.StringValue("DefaultName")),
 				},
-				Computed:    true,
+				Computed: true,
+				Validators: []tfsdk.AttributeValidator{
+					stringvalidator.OneOf(
+						string(offloadingv1alpha1.EnforceSameNameMappingStrategyType),
+						string(offloadingv1alpha1.DefaultNameMappingStrategyType),
+					),
+				},
 				Description: "Naming strategy used to create the remote namespace.",
 			},
 			"cluster_selector_terms": {
 				Optional: true,
 				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"match_labels": {
+						Type:        types.MapType{ElemType: types.StringType},
+						Optional:    true,
+						Description: "Map of cluster labels, each entry is equivalent to a match_expressions element with the In operator and a single value.",
+					},
 					"match_expressions": {
-						Optional: true,
-						Computed: true,
-						Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
-							"key": {
-								Type:        types.StringType,
-								Required:    true,
-								Description: " The label key that the selector applies to.",
-							},
-							"operator": {
-								Type:        types.StringType,
-								Required:    true,
-								Description: "Represents a key's relationship to a set of values.",
-							},
-							"values": {
-								Type:        types.ListType{ElemType: types.StringType},
-								Optional:    true,
-								Description: "An array of string values.",
-							},
-						}),
+						Optional:    true,
+						Attributes:  tfsdk.ListNestedAttributes(nodeSelectorRequirementAttributes()),
 						Description: "A list of cluster selector.",
 					},
+					"match_fields": {
+						Optional:    true,
+						Attributes:  tfsdk.ListNestedAttributes(nodeSelectorRequirementAttributes()),
+						Description: "A list of cluster selector by the fields of the virtual nodes.",
+					},
 				}),
 				Description: "Selectors to restrict the set of remote clusters.",
 			},
+			"require_match": {
+				Type:     types.BoolType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.DefaultValue(types.BoolValue(false)),
+				},
+				Computed:    true,
+				Description: "Fail the apply, instead of emitting a warning, when the cluster selector does not match any virtual node. At plan time only a warning is emitted, as the virtual nodes may be created by the same apply.",
+			},
+			"wait_for_status": {
+				Type:     types.BoolType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.DefaultValue(types.BoolValue(false)),
+				},
+				Computed:    true,
+				Description: "Wait for Liqo to report the status of the offloading, so that the name of the remote namespace and the conditions of the remote namespaces are known after the apply.",
+			},
+			"timeouts": timeoutsAttribute(),
+			"offloading_phase": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Status of the offloading of the namespace towards the selected clusters.",
+			},
+			"remote_namespace_name": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Name of the namespace created in the remote clusters, chosen according to the namespace mapping strategy.",
+			},
+			"remote_namespaces_conditions": {
+				Type:        types.MapType{ElemType: remoteNamespaceConditionsType},
+				Computed:    true,
+				Description: "Conditions of the remote namespace in each remote cluster, keyed by the name of the NamespaceMap associated with the remote cluster.",
+			},
 		},
 	}, nil
 }
 
+func (o *offloadResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resource_validator.NodeSelectorRequirement(
+			path.MatchRoot("cluster_selector_terms").AtAnyListIndex().AtName("match_expressions").AtAnyListIndex(),
+		),
+		resource_validator.NodeSelectorRequirement(
+			path.MatchRoot("cluster_selector_terms").AtAnyListIndex().AtName("match_fields").AtAnyListIndex(),
+		),
+	}
+}
+
+// ModifyPlan checks in advance that the namespace exists and that the cluster selector matches at least one virtual node,
+// only warnings are emitted, and the checks are skipped when the cluster is not reachable yet or the involved values are unknown
+func (o *offloadResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	var plan offloadResourceModel
+	if diags := req.Plan.Get(ctx, &plan); diags.HasError() || plan.Namespace.IsUnknown() ||
+		clusterSelectorTermsUnknown(plan.ClusterSelectorTerms) {
+		return
+	}
+
+	CRClient, _, err := o.data.Clients()
+	if err != nil {
+		return
+	}
+
+	resp.Diagnostics.Append(checkOffloadingTargets(ctx, CRClient, plan, false)...)
+}
+
 // Creation of Offload Resource to offload a specific namespace,
 // additionally there is a possibility to select clusters with match_expressione
 // This resource will reproduce the same effect and outputs of "liqoctl offload" command
@@ -112,74 +197,45 @@ func (o *offloadResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	overrides := &clientcmd.ConfigOverrides{}
-	loader := &clientcmd.ClientConfigLoadingRules{}
-
-	configPaths := []string{}
-
-	if !o.config.KUBERNETES.KUBE_CONFIG_PATH.IsNull() {
-		configPaths = []string{o.config.KUBERNETES.KUBE_CONFIG_PATH.ValueString()}
-	} else if len(o.config.KUBERNETES.KUBE_CONFIG_PATHS) > 0 {
-		for _, configPath := range o.config.KUBERNETES.KUBE_CONFIG_PATHS {
-			configPaths = append(configPaths, configPath.ValueString())
-		}
-	} else if v := os.Getenv("KUBE_CONFIG_PATHS"); v != "" {
-		configPaths = filepath.SplitList(v)
-	}
-
-	if len(configPaths) > 0 {
-		expandedPaths := []string{}
-		for _, p := range configPaths {
-			path, err := homedir.Expand(p)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Unable to Create Resource",
-					err.Error(),
-				)
-				return
-			}
-			expandedPaths = append(expandedPaths, path)
-		}
-
-		if len(expandedPaths) == 1 {
-			loader.ExplicitPath = expandedPaths[0]
-		} else {
-			loader.Precedence = expandedPaths
-		}
-
-		ctxNotOk := o.config.KUBERNETES.KUBE_CTX.IsNull()
-		authInfoNotOk := o.config.KUBERNETES.KUBE_CTX_AUTH_INFO.IsNull()
-		clusterNotOk := o.config.KUBERNETES.KUBE_CTX_CLUSTER.IsNull()
-
-		if ctxNotOk || authInfoNotOk || clusterNotOk {
-			if ctxNotOk {
-				overrides.CurrentContext = o.config.KUBERNETES.KUBE_CTX.ValueString()
-			}
+	CRClient, _, err := o.data.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
 
-			overrides.Context = clientcmdapi.Context{}
-			if authInfoNotOk {
-				overrides.Context.AuthInfo = o.config.KUBERNETES.KUBE_CTX_AUTH_INFO.ValueString()
-			}
-			if clusterNotOk {
-				overrides.Context.Cluster = o.config.KUBERNETES.KUBE_CTX_CLUSTER.ValueString()
-			}
-		}
+	diags = checkOffloadingTargets(ctx, CRClient, plan, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if !o.config.KUBERNETES.KUBE_INSECURE.IsNull() {
-		overrides.ClusterInfo.InsecureSkipTLSVerify = !o.config.KUBERNETES.KUBE_INSECURE.ValueBool()
+	nsoff, err := enforceNamespaceOffloading(ctx, CRClient, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
 	}
-	if !o.config.KUBERNETES.KUBE_CLUSTER_CA_CERT_DATA.IsNull() {
-		overrides.ClusterInfo.CertificateAuthorityData = bytes.NewBufferString(o.config.KUBERNETES.KUBE_CLUSTER_CA_CERT_DATA.ValueString()).Bytes()
+
+	diags = plan.setNamespaceOffloadingStatus(ctx, nsoff)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if !o.config.KUBERNETES.KUBE_CLIENT_CERT_DATA.IsNull() {
-		overrides.AuthInfo.ClientCertificateData = bytes.NewBufferString(o.config.KUBERNETES.KUBE_CLIENT_CERT_DATA.ValueString()).Bytes()
+
+	// The state is saved before waiting for Liqo, so that the NamespaceOffloading is tracked even if the wait fails
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if !o.config.KUBERNETES.KUBE_HOST.IsNull() {
-		hasCA := len(overrides.ClusterInfo.CertificateAuthorityData) != 0
-		hasCert := len(overrides.AuthInfo.ClientCertificateData) != 0
-		defaultTLS := hasCA || hasCert || overrides.ClusterInfo.InsecureSkipTLSVerify
-		host, _, err := rest.DefaultServerURL(o.config.KUBERNETES.KUBE_HOST.ValueString(), "", apimachineryschema.GroupVersion{}, defaultTLS)
+
+	if plan.WaitForStatus.ValueBool() {
+		nsoff, err = waitForNamespaceOffloadingStatus(ctx, CRClient, nsoff, plan.Timeouts.CreateTimeout())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to Create Resource",
@@ -188,140 +244,142 @@ func (o *offloadResource) Create(ctx context.Context, req resource.CreateRequest
 			return
 		}
 
-		overrides.ClusterInfo.Server = host.String()
-	}
-	if !o.config.KUBERNETES.KUBE_USER.IsNull() {
-		overrides.AuthInfo.Username = o.config.KUBERNETES.KUBE_USER.ValueString()
-	}
-	if !o.config.KUBERNETES.KUBE_PASSWORD.IsNull() {
-		overrides.AuthInfo.Password = o.config.KUBERNETES.KUBE_PASSWORD.ValueString()
-	}
-	if !o.config.KUBERNETES.KUBE_CLIENT_KEY_DATA.IsNull() {
-		overrides.AuthInfo.ClientKeyData = bytes.NewBufferString(o.config.KUBERNETES.KUBE_CLIENT_KEY_DATA.ValueString()).Bytes()
-	}
-	if !o.config.KUBERNETES.KUBE_TOKEN.IsNull() {
-		overrides.AuthInfo.Token = o.config.KUBERNETES.KUBE_TOKEN.ValueString()
-	}
-
-	if !o.config.KUBERNETES.KUBE_PROXY_URL.IsNull() {
-		overrides.ClusterDefaults.ProxyURL = o.config.KUBERNETES.KUBE_PROXY_URL.ValueString()
-	}
-
-	if len(o.config.KUBERNETES.KUBE_EXEC) > 0 {
-		exec := &clientcmdapi.ExecConfig{}
-		exec.InteractiveMode = clientcmdapi.IfAvailableExecInteractiveMode
-		exec.APIVersion = o.config.KUBERNETES.KUBE_EXEC[0].API_VERSION.ValueString()
-		exec.Command = o.config.KUBERNETES.KUBE_EXEC[0].COMMAND.ValueString()
-		for _, arg := range o.config.KUBERNETES.KUBE_EXEC[0].ARGS {
-			exec.Args = append(exec.Args, arg.ValueString())
-		}
-
-		for kk, vv := range o.config.KUBERNETES.KUBE_EXEC[0].ENV.Elements() {
-			exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: kk, Value: vv.String()})
+		diags = plan.setNamespaceOffloadingStatus(ctx, nsoff)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
 
-		overrides.AuthInfo.Exec = exec
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
 	}
+}
 
-	clientCfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
-	if clientCfg == nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			"Unable to Create Resource while creating clientCfg",
-		)
+// Read refreshes the Offload Resource from the NamespaceOffloading of the offloaded namespace,
+// the resource is removed from the state if the NamespaceOffloading does not exist anymore
+func (o *offloadResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state offloadResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var restCfg *rest.Config
-
-	restCfg, err := clientCfg.ClientConfig()
+	CRClient, _, err := o.data.Clients()
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
+			"Unable to Read Resource",
 			err.Error(),
 		)
 		return
 	}
 
-	var CRClient client.Client
-
-	CRClient, err = client.New(restCfg, client.Options{Scheme: scheme.Scheme})
-	if err != nil {
+	var nsoff offloadingv1alpha1.NamespaceOffloading
+	err = CRClient.Get(ctx, kubeTypes.NamespacedName{Name: consts.DefaultNamespaceOffloadingName, Namespace: state.Namespace.ValueString()}, &nsoff)
+	if kerrors.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
+			"Unable to Read Resource",
 			err.Error(),
 		)
 		return
 	}
 
-	var clusterSelector [][]metav1.LabelSelectorRequirement
-
-	for _, selector := range plan.ClusterSelectorTerms {
-		s := &metav1.LabelSelector{
-			MatchLabels:      map[string]string{},
-			MatchExpressions: []metav1.LabelSelectorRequirement{},
-		}
-
-		for _, match_expression := range selector.MatchExpressions {
-
-			var values []string
-
-			for _, value := range match_expression.Values {
-				values = append(values, value.ValueString())
-			}
-			req := metav1.LabelSelectorRequirement{
-				Key:      match_expression.Key.ValueString(),
-				Operator: metav1.LabelSelectorOperator(match_expression.Operator.ValueString()),
-				Values:   values,
-			}
-			s.MatchExpressions = append(s.MatchExpressions, req)
-		}
-
-		clusterSelector = append(clusterSelector, s.MatchExpressions)
+	if state.RequireMatch.IsNull() {
+		state.RequireMatch = types.BoolValue(false)
+	}
+	if state.WaitForStatus.IsNull() {
+		state.WaitForStatus = types.BoolValue(false)
+	}
+	state.PodOffloadingStrategy = types.StringValue(string(nsoff.Spec.PodOffloadingStrategy))
+	state.NamespaceMappingStrategy = types.StringValue(string(nsoff.Spec.NamespaceMappingStrategy))
+	state.ClusterSelectorTerms = clusterSelectorTermsFromNodeSelector(nsoff.Spec.ClusterSelector, state.ClusterSelectorTerms)
+	diags = state.setNamespaceOffloadingStatus(ctx, &nsoff)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	terms := []corev1.NodeSelectorTerm{}
-
-	for _, selector := range clusterSelector {
-		var requirements []corev1.NodeSelectorRequirement
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
 
-		for _, r := range selector {
-			requirements = append(requirements, corev1.NodeSelectorRequirement{
-				Key:      r.Key,
-				Operator: corev1.NodeSelectorOperator(r.Operator),
-				Values:   r.Values,
-			})
-		}
+// Update of Offload Resource reconciles in place the NamespaceOffloading of the namespace,
+// a change of the namespace itself requires the replacement of the resource
+func (o *offloadResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan offloadResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		terms = append(terms, corev1.NodeSelectorTerm{MatchExpressions: requirements})
+	CRClient, _, err := o.data.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resource",
+			err.Error(),
+		)
+		return
 	}
 
-	nsoff := &offloadingv1alpha1.NamespaceOffloading{ObjectMeta: metav1.ObjectMeta{
-		Name: consts.DefaultNamespaceOffloadingName, Namespace: plan.Namespace.ValueString()}}
+	diags = checkOffloadingTargets(ctx, CRClient, plan, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	_, err = controllerutil.CreateOrUpdate(ctx, CRClient, nsoff, func() error {
-		nsoff.Spec.PodOffloadingStrategy = offloadingv1alpha1.PodOffloadingStrategyType(plan.PodOffloadingStrategy.ValueString())
-		nsoff.Spec.NamespaceMappingStrategy = offloadingv1alpha1.NamespaceMappingStrategyType(plan.NamespaceMappingStrategy.ValueString())
-		nsoff.Spec.ClusterSelector = corev1.NodeSelector{NodeSelectorTerms: terms}
-		return nil
-	})
+	nsoff, err := enforceNamespaceOffloading(ctx, CRClient, plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
+			"Unable to Update Resource",
 			err.Error(),
 		)
+		return
+	}
 
+	diags = plan.setNamespaceOffloadingStatus(ctx, nsoff)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// The state is saved before waiting for Liqo, so that the NamespaceOffloading is tracked even if the wait fails
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if plan.WaitForStatus.ValueBool() {
+		nsoff, err = waitForNamespaceOffloadingStatus(ctx, CRClient, nsoff, plan.Timeouts.UpdateTimeout())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Update Resource",
+				err.Error(),
+			)
+			return
+		}
+
+		diags = plan.setNamespaceOffloadingStatus(ctx, nsoff)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+	}
 }
 
-func (o *offloadResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+// Delete of Offload Resource removes the NamespaceOffloading and waits for Liqo to remove the remote namespaces,
+// so that the local namespace can be safely deleted afterwards
+func (o *offloadResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state offloadResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -329,191 +387,381 @@ func (o *offloadResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	diags = resp.State.Set(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	CRClient, _, err := o.data.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	nsoff := &offloadingv1alpha1.NamespaceOffloading{ObjectMeta: metav1.ObjectMeta{
+		Name: consts.DefaultNamespaceOffloadingName, Namespace: state.Namespace.ValueString()}}
+	if err := CRClient.Delete(ctx, nsoff); client.IgnoreNotFound(err) != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := waitForUnoffloaded(ctx, CRClient, state.Namespace.ValueString(), state.Timeouts.DeleteTimeout()); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource",
+			err.Error(),
+		)
 		return
 	}
 }
 
-func (o *offloadResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Unable to Update Resource",
-		"Update is not supported/permitted yet.",
-	)
+// ImportState adopts the NamespaceOffloading of an already offloaded namespace, the identifier is the namespace name
+func (o *offloadResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("namespace"), req, resp)
 }
 
-func (o *offloadResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+// Configure method to obtain kubernetes Clients provided by provider
+func (o *offloadResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
 
-	var data offloadResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	o.data = req.ProviderData.(*liqoProviderData)
+}
 
-	overrides := &clientcmd.ConfigOverrides{}
-	loader := &clientcmd.ClientConfigLoadingRules{}
+// enforceNamespaceOffloading creates or updates the NamespaceOffloading of the namespace to match the given plan
+func enforceNamespaceOffloading(ctx context.Context, CRClient client.Client, plan offloadResourceModel) (*offloadingv1alpha1.NamespaceOffloading, error) {
+	terms := nodeSelectorTermsFromClusterSelectorTerms(plan.ClusterSelectorTerms)
 
-	configPaths := []string{}
+	nsoff := &offloadingv1alpha1.NamespaceOffloading{ObjectMeta: metav1.ObjectMeta{
+		Name: consts.DefaultNamespaceOffloadingName, Namespace: plan.Namespace.ValueString()}}
 
-	if !o.config.KUBERNETES.KUBE_CONFIG_PATH.IsNull() {
-		configPaths = []string{o.config.KUBERNETES.KUBE_CONFIG_PATH.ValueString()}
-	} else if len(o.config.KUBERNETES.KUBE_CONFIG_PATHS) > 0 {
-		for _, configPath := range o.config.KUBERNETES.KUBE_CONFIG_PATHS {
-			configPaths = append(configPaths, configPath.ValueString())
-		}
-	} else if v := os.Getenv("KUBE_CONFIG_PATHS"); v != "" {
-		configPaths = filepath.SplitList(v)
-	}
-
-	if len(configPaths) > 0 {
-		expandedPaths := []string{}
-		for _, p := range configPaths {
-			path, err := homedir.Expand(p)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Unable to Create Resource",
-					err.Error(),
-				)
-				return
-			}
-			expandedPaths = append(expandedPaths, path)
-		}
+	_, err := controllerutil.CreateOrUpdate(ctx, CRClient, nsoff, func() error {
+		nsoff.Spec.PodOffloadingStrategy = offloadingv1alpha1.PodOffloadingStrategyType(plan.PodOffloadingStrategy.ValueString())
+		nsoff.Spec.NamespaceMappingStrategy = offloadingv1alpha1.NamespaceMappingStrategyType(plan.NamespaceMappingStrategy.ValueString())
+		nsoff.Spec.ClusterSelector = corev1.NodeSelector{NodeSelectorTerms: terms}
+		return nil
+	})
 
-		if len(expandedPaths) == 1 {
-			loader.ExplicitPath = expandedPaths[0]
-		} else {
-			loader.Precedence = expandedPaths
+	return nsoff, err
+}
+
+// checkOffloadingTargets verifies that the namespace exists and that the cluster selector matches at least one virtual node,
+// listing the available virtual nodes otherwise. A missing namespace is an error only when apply is set, while a selector
+// matching no virtual node is an error only when both apply and require_match are set. At plan time (apply not set)
+// the checks are skipped when the cluster cannot be queried, as it may be unreachable or not created yet
+func checkOffloadingTargets(ctx context.Context, CRClient client.Client, plan offloadResourceModel, apply bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var namespace corev1.Namespace
+	err := CRClient.Get(ctx, kubeTypes.NamespacedName{Name: plan.Namespace.ValueString()}, &namespace)
+	switch {
+	case kerrors.IsNotFound(err) && apply:
+		diags.AddAttributeError(path.Root("namespace"), "Namespace Not Found",
+			fmt.Sprintf("The namespace %q does not exist.", plan.Namespace.ValueString()))
+	case kerrors.IsNotFound(err):
+		diags.AddAttributeWarning(path.Root("namespace"), "Namespace Not Found",
+			fmt.Sprintf("The namespace %q does not exist yet, it must be created before the offloading.", plan.Namespace.ValueString()))
+	case err != nil && !apply:
+		return diags
+	case err != nil:
+		diags.AddError("Unable to Check Namespace", err.Error())
+	}
+
+	var nodes corev1.NodeList
+	if err := CRClient.List(ctx, &nodes, client.MatchingLabels{consts.TypeLabel: consts.TypeNode}); err != nil {
+		if apply {
+			diags.AddError("Unable to List Virtual Nodes", err.Error())
 		}
+		return diags
+	}
 
-		ctxNotOk := o.config.KUBERNETES.KUBE_CTX.IsNull()
-		authInfoNotOk := o.config.KUBERNETES.KUBE_CTX_AUTH_INFO.IsNull()
-		clusterNotOk := o.config.KUBERNETES.KUBE_CTX_CLUSTER.IsNull()
+	selector := &corev1.NodeSelector{NodeSelectorTerms: nodeSelectorTermsFromClusterSelectorTerms(plan.ClusterSelectorTerms)}
 
-		if ctxNotOk || authInfoNotOk || clusterNotOk {
-			if ctxNotOk {
-				overrides.CurrentContext = o.config.KUBERNETES.KUBE_CTX.ValueString()
+	var available []string
+	for i := range nodes.Items {
+		matches := true
+		if len(selector.NodeSelectorTerms) > 0 {
+			if matches, err = k8shelper.MatchNodeSelectorTerms(&nodes.Items[i], selector); err != nil {
+				diags.AddAttributeError(path.Root("cluster_selector_terms"), "Invalid Cluster Selector", err.Error())
+				return diags
 			}
+		}
+		if matches {
+			return diags
+		}
 
-			overrides.Context = clientcmdapi.Context{}
-			if authInfoNotOk {
-				overrides.Context.AuthInfo = o.config.KUBERNETES.KUBE_CTX_AUTH_INFO.ValueString()
-			}
-			if clusterNotOk {
-				overrides.Context.Cluster = o.config.KUBERNETES.KUBE_CTX_CLUSTER.ValueString()
-			}
+		labels := []string{}
+		for key, value := range nodes.Items[i].Labels {
+			labels = append(labels, key+"="+value)
 		}
+		sort.Strings(labels)
+		available = append(available, fmt.Sprintf("- %s: %s", nodes.Items[i].Name, strings.Join(labels, ", ")))
 	}
 
-	if !o.config.KUBERNETES.KUBE_INSECURE.IsNull() {
-		overrides.ClusterInfo.InsecureSkipTLSVerify = !o.config.KUBERNETES.KUBE_INSECURE.ValueBool()
-	}
-	if !o.config.KUBERNETES.KUBE_CLUSTER_CA_CERT_DATA.IsNull() {
-		overrides.ClusterInfo.CertificateAuthorityData = bytes.NewBufferString(o.config.KUBERNETES.KUBE_CLUSTER_CA_CERT_DATA.ValueString()).Bytes()
+	summary := "The cluster selector does not match any virtual node."
+	if len(available) == 0 {
+		summary = "No virtual node is available, the namespace will not be offloaded to any remote cluster."
+	} else {
+		summary += " Available virtual nodes:\n" + strings.Join(available, "\n")
 	}
-	if !o.config.KUBERNETES.KUBE_CLIENT_CERT_DATA.IsNull() {
-		overrides.AuthInfo.ClientCertificateData = bytes.NewBufferString(o.config.KUBERNETES.KUBE_CLIENT_CERT_DATA.ValueString()).Bytes()
+
+	if apply && plan.RequireMatch.ValueBool() {
+		diags.AddAttributeError(path.Root("cluster_selector_terms"), "No Matching Virtual Node", summary)
+	} else {
+		diags.AddAttributeWarning(path.Root("cluster_selector_terms"), "No Matching Virtual Node", summary)
 	}
-	if !o.config.KUBERNETES.KUBE_HOST.IsNull() {
-		hasCA := len(overrides.ClusterInfo.CertificateAuthorityData) != 0
-		hasCert := len(overrides.AuthInfo.ClientCertificateData) != 0
-		defaultTLS := hasCA || hasCert || overrides.ClusterInfo.InsecureSkipTLSVerify
-		host, _, err := rest.DefaultServerURL(o.config.KUBERNETES.KUBE_HOST.ValueString(), "", apimachineryschema.GroupVersion{}, defaultTLS)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Create Resource",
-				err.Error(),
-			)
-			return
+	return diags
+}
+
+// waitForNamespaceOffloadingStatus polls the given NamespaceOffloading until Liqo has realigned its status to the current generation,
+// so that the remote namespace name and the per-cluster conditions are available, or the timeout expires
+func waitForNamespaceOffloadingStatus(ctx context.Context, CRClient client.Client, nsoff *offloadingv1alpha1.NamespaceOffloading,
+	timeout time.Duration) (*offloadingv1alpha1.NamespaceOffloading, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	generation := nsoff.Generation
+	current := &offloadingv1alpha1.NamespaceOffloading{}
+
+	err := wait.PollImmediateUntilWithContext(ctx, time.Second, func(ctx context.Context) (bool, error) {
+		if err := CRClient.Get(ctx, client.ObjectKeyFromObject(nsoff), current); err != nil {
+			return false, err
 		}
+		return current.Status.ObservedGeneration >= generation && current.Status.RemoteNamespaceName != "", nil
+	})
 
-		overrides.ClusterInfo.Server = host.String()
-	}
-	if !o.config.KUBERNETES.KUBE_USER.IsNull() {
-		overrides.AuthInfo.Username = o.config.KUBERNETES.KUBE_USER.ValueString()
-	}
-	if !o.config.KUBERNETES.KUBE_PASSWORD.IsNull() {
-		overrides.AuthInfo.Password = o.config.KUBERNETES.KUBE_PASSWORD.ValueString()
-	}
-	if !o.config.KUBERNETES.KUBE_CLIENT_KEY_DATA.IsNull() {
-		overrides.AuthInfo.ClientKeyData = bytes.NewBufferString(o.config.KUBERNETES.KUBE_CLIENT_KEY_DATA.ValueString()).Bytes()
-	}
-	if !o.config.KUBERNETES.KUBE_TOKEN.IsNull() {
-		overrides.AuthInfo.Token = o.config.KUBERNETES.KUBE_TOKEN.ValueString()
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return nil, fmt.Errorf("timed out after %s waiting for the NamespaceOffloading status to be updated by Liqo", timeout)
 	}
+	return current, err
+}
 
-	if !o.config.KUBERNETES.KUBE_PROXY_URL.IsNull() {
-		overrides.ClusterDefaults.ProxyURL = o.config.KUBERNETES.KUBE_PROXY_URL.ValueString()
-	}
+// waitForUnoffloaded polls the NamespaceOffloading of the given namespace until it has been removed,
+// which happens once the remote namespaces have been deleted from every selected cluster, or the timeout expires
+func waitForUnoffloaded(ctx context.Context, CRClient client.Client, namespace string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pending := ""
+
+	err := wait.PollImmediateUntilWithContext(ctx, time.Second, func(ctx context.Context) (bool, error) {
+		var nsoff offloadingv1alpha1.NamespaceOffloading
+		err := CRClient.Get(ctx, kubeTypes.NamespacedName{Name: consts.DefaultNamespaceOffloadingName, Namespace: namespace}, &nsoff)
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		} else if err != nil {
+			return false, err
+		}
 
-	if len(o.config.KUBERNETES.KUBE_EXEC) > 0 {
-		exec := &clientcmdapi.ExecConfig{}
-		exec.InteractiveMode = clientcmdapi.IfAvailableExecInteractiveMode
-		exec.APIVersion = o.config.KUBERNETES.KUBE_EXEC[0].API_VERSION.ValueString()
-		exec.Command = o.config.KUBERNETES.KUBE_EXEC[0].COMMAND.ValueString()
-		for _, arg := range o.config.KUBERNETES.KUBE_EXEC[0].ARGS {
-			exec.Args = append(exec.Args, arg.ValueString())
+		namespaceMaps := make([]string, 0, len(nsoff.Status.RemoteNamespacesConditions))
+		for namespaceMap := range nsoff.Status.RemoteNamespacesConditions {
+			namespaceMaps = append(namespaceMaps, namespaceMap)
 		}
+		sort.Strings(namespaceMaps)
 
-		for kk, vv := range o.config.KUBERNETES.KUBE_EXEC[0].ENV.Elements() {
-			exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: kk, Value: vv.String()})
+		if len(namespaceMaps) > 0 {
+			pending = fmt.Sprintf("the remote namespaces have not been removed yet from the clusters of the NamespaceMaps %s", strings.Join(namespaceMaps, ", "))
+		} else {
+			pending = fmt.Sprintf("the NamespaceOffloading is in phase %q", nsoff.Status.OffloadingPhase)
 		}
+		return false, nil
+	})
 
-		overrides.AuthInfo.Exec = exec
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return fmt.Errorf("timed out after %s waiting for the namespace to be unoffloaded, %s", timeout, pending)
 	}
+	return err
+}
 
-	clientCfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
-	if clientCfg == nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			"Unable to Create Resource while creating clientCfg",
-		)
-		return
+// remoteNamespaceConditionsType is the type of the conditions of the remote namespace in a single remote cluster
+var remoteNamespaceConditionsType = types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+	"type":    types.StringType,
+	"status":  types.StringType,
+	"reason":  types.StringType,
+	"message": types.StringType,
+}}}
+
+// setNamespaceOffloadingStatus copies into the model the status of the NamespaceOffloading
+func (m *offloadResourceModel) setNamespaceOffloadingStatus(ctx context.Context, nsoff *offloadingv1alpha1.NamespaceOffloading) diag.Diagnostics {
+	m.OffloadingPhase = types.StringValue(string(nsoff.Status.OffloadingPhase))
+	m.RemoteNamespaceName = types.StringValue(nsoff.Status.RemoteNamespaceName)
+
+	conditions := map[string][]remoteNamespaceCondition{}
+	for namespaceMap := range nsoff.Status.RemoteNamespacesConditions {
+		conditions[namespaceMap] = []remoteNamespaceCondition{}
+		for _, condition := range nsoff.Status.RemoteNamespacesConditions[namespaceMap] {
+			conditions[namespaceMap] = append(conditions[namespaceMap], remoteNamespaceCondition{
+				Type:    types.StringValue(string(condition.Type)),
+				Status:  types.StringValue(string(condition.Status)),
+				Reason:  types.StringValue(condition.Reason),
+				Message: types.StringValue(condition.Message),
+			})
+		}
 	}
 
-	var restCfg *rest.Config
+	var diags diag.Diagnostics
+	m.RemoteNamespacesConditions, diags = types.MapValueFrom(ctx, remoteNamespaceConditionsType, conditions)
+	return diags
+}
 
-	restCfg, err := clientCfg.ClientConfig()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			err.Error(),
-		)
-		return
+// nodeSelectorRequirementAttributes returns the attributes of a single requirement of match_expressions and match_fields
+func nodeSelectorRequirementAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"key": {
+			Type:        types.StringType,
+			Required:    true,
+			Description: " The label key that the selector applies to.",
+		},
+		"operator": {
+			Type:     types.StringType,
+			Required: true,
+			Validators: []tfsdk.AttributeValidator{
+				stringvalidator.OneOf(
+					string(corev1.NodeSelectorOpIn),
+					string(corev1.NodeSelectorOpNotIn),
+					string(corev1.NodeSelectorOpExists),
+					string(corev1.NodeSelectorOpDoesNotExist),
+					string(corev1.NodeSelectorOpGt),
+					string(corev1.NodeSelectorOpLt),
+				),
+			},
+			Description: "Represents a key's relationship to a set of values.",
+		},
+		"values": {
+			Type:        types.ListType{ElemType: types.StringType},
+			Optional:    true,
+			Description: "An array of string values.",
+		},
 	}
+}
 
-	var CRClient client.Client
+// nodeSelectorTermsFromClusterSelectorTerms converts cluster_selector_terms into the terms of the ClusterSelector of a NamespaceOffloading,
+// match_labels are expanded into In requirements preceding the match_expressions ones
+func nodeSelectorTermsFromClusterSelectorTerms(clusterSelectorTerms []match_expressions) []corev1.NodeSelectorTerm {
+	terms := []corev1.NodeSelectorTerm{}
 
-	CRClient, err = client.New(restCfg, client.Options{Scheme: scheme.Scheme})
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			err.Error(),
-		)
-		return
+	for _, selector := range clusterSelectorTerms {
+		var term corev1.NodeSelectorTerm
+
+		keys := make([]string, 0, len(selector.MatchLabels))
+		for key := range selector.MatchLabels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			term.MatchExpressions = append(term.MatchExpressions, corev1.NodeSelectorRequirement{
+				Key:      key,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{selector.MatchLabels[key].ValueString()},
+			})
+		}
+
+		term.MatchExpressions = append(term.MatchExpressions, nodeSelectorRequirementsFromMatchExpressions(selector.MatchExpressions)...)
+		term.MatchFields = nodeSelectorRequirementsFromMatchExpressions(selector.MatchFields)
+
+		terms = append(terms, term)
 	}
 
-	if CRClient == nil {
-		return
+	return terms
+}
+
+// clusterSelectorTermsUnknown checks whether any value of the cluster selector terms is not known yet
+func clusterSelectorTermsUnknown(clusterSelectorTerms []match_expressions) bool {
+	for _, selector := range clusterSelectorTerms {
+		for _, value := range selector.MatchLabels {
+			if value.IsUnknown() {
+				return true
+			}
+		}
+
+		for _, match_expression := range append(append([]match_expression{}, selector.MatchExpressions...), selector.MatchFields...) {
+			if match_expression.Key.IsUnknown() || match_expression.Operator.IsUnknown() {
+				return true
+			}
+			for _, value := range match_expression.Values {
+				if value.IsUnknown() {
+					return true
+				}
+			}
+		}
 	}
 
-	nsoff := &offloadingv1alpha1.NamespaceOffloading{ObjectMeta: metav1.ObjectMeta{
-		Name: consts.DefaultNamespaceOffloadingName, Namespace: data.Namespace.ValueString()}}
-	if err := CRClient.Delete(ctx, nsoff); client.IgnoreNotFound(err) != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Delete Resource",
-			err.Error(),
-		)
-		return
+	return false
+}
+
+func nodeSelectorRequirementsFromMatchExpressions(expressions []match_expression) []corev1.NodeSelectorRequirement {
+	var requirements []corev1.NodeSelectorRequirement
+
+	for _, match_expression := range expressions {
+		var values []string
+
+		for _, value := range match_expression.Values {
+			values = append(values, value.ValueString())
+		}
+		requirements = append(requirements, corev1.NodeSelectorRequirement{
+			Key:      match_expression.Key.ValueString(),
+			Operator: corev1.NodeSelectorOperator(match_expression.Operator.ValueString()),
+			Values:   values,
+		})
 	}
 
+	return requirements
 }
 
-// Configure method to obtain kubernetes Clients provided by provider
-func (o *offloadResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
-		return
+// clusterSelectorTermsFromNodeSelector converts the ClusterSelector of a NamespaceOffloading back into cluster_selector_terms,
+// the In requirements with a single value are reported as match_labels when they were configured as such in the prior terms
+func clusterSelectorTermsFromNodeSelector(selector corev1.NodeSelector, prior []match_expressions) []match_expressions {
+	var terms []match_expressions
+
+	for i, term := range selector.NodeSelectorTerms {
+		var priorLabels map[string]types.String
+		if i < len(prior) {
+			priorLabels = prior[i].MatchLabels
+		}
+
+		var labels map[string]types.String
+		var expressions []corev1.NodeSelectorRequirement
+
+		for _, r := range term.MatchExpressions {
+			if _, ok := priorLabels[r.Key]; ok && r.Operator == corev1.NodeSelectorOpIn && len(r.Values) == 1 {
+				if labels == nil {
+					labels = map[string]types.String{}
+				}
+				labels[r.Key] = types.StringValue(r.Values[0])
+				continue
+			}
+			expressions = append(expressions, r)
+		}
+
+		terms = append(terms, match_expressions{
+			MatchLabels:      labels,
+			MatchExpressions: matchExpressionsFromNodeSelectorRequirements(expressions),
+			MatchFields:      matchExpressionsFromNodeSelectorRequirements(term.MatchFields),
+		})
 	}
 
-	o.config = req.ProviderData.(liqoProviderModel)
+	return terms
+}
+
+func matchExpressionsFromNodeSelectorRequirements(requirements []corev1.NodeSelectorRequirement) []match_expression {
+	var expressions []match_expression
+
+	for _, r := range requirements {
+		var values []types.String
+
+		for _, value := range r.Values {
+			values = append(values, types.StringValue(value))
+		}
+		expressions = append(expressions, match_expression{
+			Key:      types.StringValue(r.Key),
+			Operator: types.StringValue(string(r.Operator)),
+			Values:   values,
+		})
+	}
 
+	return expressions
 }
 
 type match_expression struct {
@@ -523,7 +771,9 @@ type match_expression struct {
 }
 
 type match_expressions struct {
-	MatchExpressions []match_expression `tfsdk:"match_expressions"`
+	MatchLabels      map[string]types.String `tfsdk:"match_labels"`
+	MatchExpressions []match_expression      `tfsdk:"match_expressions"`
+	MatchFields      []match_expression      `tfsdk:"match_fields"`
 }
 
 type offloadResourceModel struct {
@@ -531,4 +781,18 @@ type offloadResourceModel struct {
 	PodOffloadingStrategy    types.String        `tfsdk:"pod_offloading_strategy"`
 	NamespaceMappingStrategy types.String        `tfsdk:"namespace_mapping_strategy"`
 	ClusterSelectorTerms     []match_expressions `tfsdk:"cluster_selector_terms"`
+	RequireMatch             types.Bool          `tfsdk:"require_match"`
+	WaitForStatus            types.Bool          `tfsdk:"wait_for_status"`
+	Timeouts                 *timeouts           `tfsdk:"timeouts"`
+
+	OffloadingPhase            types.String `tfsdk:"offloading_phase"`
+	RemoteNamespaceName        types.String `tfsdk:"remote_namespace_name"`
+	RemoteNamespacesConditions types.Map    `tfsdk:"remote_namespaces_conditions"`
+}
+
+type remoteNamespaceCondition struct {
+	Type    types.String `tfsdk:"type"`
+	Status  types.String `tfsdk:"status"`
+	Reason  types.String `tfsdk:"reason"`
+	Message types.String `tfsdk:"message"`
 }
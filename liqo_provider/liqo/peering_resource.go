@@ -1,40 +1,50 @@
 package liqo
 
 import (
-	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"net/http"
+	"strings"
 	"terraform-provider-liqo/liqo/attribute_plan_modifier"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	discoveryv1alpha1 "github.com/liqotech/liqo/apis/discovery/v1alpha1"
+	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/discovery"
+	discoveryutils "github.com/liqotech/liqo/pkg/discoverymanager/utils"
 	"github.com/liqotech/liqo/pkg/utils"
 	authenticationtokenutils "github.com/liqotech/liqo/pkg/utils/authenticationtoken"
 	foreigncluster "github.com/liqotech/liqo/pkg/utils/foreignCluster"
-	"github.com/mitchellh/go-homedir"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	peeringconditionsutils "github.com/liqotech/liqo/pkg/utils/peeringConditions"
+	"github.com/liqotech/liqo/pkg/virtualKubelet"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	apimachineryschema "k8s.io/apimachinery/pkg/runtime/schema"
 	kubeTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
-	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
-	"k8s.io/kubectl/pkg/scheme"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 var (
-	_ resource.Resource              = &peeringResource{}
-	_ resource.ResourceWithConfigure = &peeringResource{}
+	_ resource.Resource                     = &peeringResource{}
+	_ resource.ResourceWithConfigure        = &peeringResource{}
+	_ resource.ResourceWithConfigValidators = &peeringResource{}
+	_ resource.ResourceWithImportState      = &peeringResource{}
+	_ resource.ResourceWithModifyPlan       = &peeringResource{}
 )
 
 func NewPeeringResource() resource.Resource {
@@ -42,7 +52,7 @@ func NewPeeringResource() resource.Resource {
 }
 
 type peeringResource struct {
-	config liqoProviderModel
+	data *liqoProviderData
 }
 
 func (p *peeringResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,16 +61,22 @@ func (p *peeringResource) Metadata(_ context.Context, req resource.MetadataReque
 
 func (p *peeringResource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
-		Description: "Execute peering.",
+		Description: "Execute peering. An imported peering reads the authentication token from the Secret stored by Liqo into the sensitive cluster_token, so that the following plan is empty when it matches the configured one.",
 		Attributes: map[string]tfsdk.Attribute{
 			"cluster_id": {
-				Type:        types.StringType,
-				Required:    true,
+				Type:     types.StringType,
+				Required: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
 				Description: "Provider cluster ID used for peering.",
 			},
 			"cluster_name": {
-				Type:        types.StringType,
-				Required:    true,
+				Type:     types.StringType,
+				Required: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.RequiresReplace(),
+				},
 				Description: "Provider cluster name used for peering.",
 			},
 			"cluster_authurl": {
@@ -70,22 +86,200 @@ func (p *peeringResource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagn
 			},
 			"cluster_token": {
 				Type:        types.StringType,
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
 				Description: "Provider authentication token used for peering.",
 			},
+			"cluster_token_secret_ref": {
+				Optional: true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"name": {
+						Type:        types.StringType,
+						Required:    true,
+						Description: "Name of the Secret.",
+					},
+					"namespace": {
+						Type:     types.StringType,
+						Optional: true,
+						PlanModifiers: []tfsdk.AttributePlanModifier{
+							attribute_plan_modifier.DefaultValue(types.StringValue("default")),
+						},
+						Computed:    true,
+						Description: "Namespace of the Secret.",
+					},
+					"key": {
+						Type:     types.StringType,
+						Optional: true,
+						PlanModifiers: []tfsdk.AttributePlanModifier{
+							attribute_plan_modifier.DefaultValue(types.StringValue("token")),
+						},
+						Computed:    true,
+						Description: "Key of the Secret containing the token.",
+					},
+				}),
+				Description: "Secret in the local cluster containing the provider authentication token used for peering, so that the token is never stored in the Terraform state.",
+			},
 			"liqo_namespace": {
 				Type:     types.StringType,
 				Optional: true,
 				PlanModifiers: []tfsdk.AttributePlanModifier{
 					attribute_plan_modifier.DefaultValue(types.StringValue("liqo")),
+					resource.RequiresReplace(),
 				},
 				Computed:    true,
 				Description: "Namespace where is Liqo installed in provider cluster.",
 			},
+			"cluster_proxy_url": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "URL of the proxy through which the provider authentication service is reached.",
+			},
+			"incoming_peering_enabled": {
+				Type:     types.StringType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.DefaultValue(types.StringValue(string(discoveryv1alpha1.PeeringEnabledAuto))),
+				},
+				Computed: true,
+				Validators: []tfsdk.AttributeValidator{
+					stringvalidator.OneOf(
+						string(discoveryv1alpha1.PeeringEnabledAuto),
+						string(discoveryv1alpha1.PeeringEnabledYes),
+						string(discoveryv1alpha1.PeeringEnabledNo),
+					),
+				},
+				Description: "Whether the incoming peering from the provider cluster is enabled (Auto, Yes or No), set it to No to peer one-way.",
+			},
+			"ca_bundle": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "PEM-encoded CA bundle (e.g. the auth_ca of liqo_generate) the provider verifies the certificate of the provider authentication service with before peering. It is only used by this check of the provider and is not passed to Liqo, whose controller manager verifies the certificate against its own trusted CAs: a self-signed authentication service still requires insecure_skip_tls_verify = true, which cannot be combined with ca_bundle.",
+			},
+			"insecure_skip_tls_verify": {
+				Type:     types.BoolType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.CreateDefaultValue(types.BoolValue(false), types.BoolValue(true)),
+				},
+				Computed:    true,
+				Description: "Skip the verification of the TLS certificate of the provider authentication service, a warning is emitted when enabled. Defaults to false for new peerings, while the existing ones keep their current setting. It must be set to true when the authentication service exposes a self-signed certificate, or one signed by a CA not trusted by the Liqo controller manager, even if that CA is known: ca_bundle is not passed to Liqo.",
+			},
+			"wait_for_established": {
+				Type:     types.BoolType,
+				Optional: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					attribute_plan_modifier.DefaultValue(types.BoolValue(false)),
+				},
+				Computed:    true,
+				Description: "Wait for the authentication, outgoing peering and network conditions to be established and for the virtual node to be ready.",
+			},
+			"timeouts": timeoutsAttribute(),
+			"peering_type": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Type of the peering established with the provider cluster.",
+			},
+			"outgoing_peering_enabled": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Whether the outgoing peering towards the provider cluster is enabled.",
+			},
+			"authentication_status": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Status of the authentication with the provider cluster.",
+			},
+			"incoming_peering_status": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Status of the incoming peering from the provider cluster.",
+			},
+			"outgoing_peering_status": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Status of the outgoing peering towards the provider cluster.",
+			},
+			"network_status": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Status of the network connectivity with the provider cluster.",
+			},
+			"local_tenant_namespace": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Tenant namespace in the local cluster assigned to the provider cluster.",
+			},
+			"remote_tenant_namespace": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Tenant namespace in the provider cluster assigned to the local cluster.",
+			},
+			"virtual_node_name": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					resource.UseStateForUnknown(),
+				},
+				Description: "Name of the virtual node representing the provider cluster.",
+			},
 		},
 	}, nil
 }
 
+func (p *peeringResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("cluster_token"),
+			path.MatchRoot("cluster_token_secret_ref"),
+		),
+	}
+}
+
+// ModifyPlan warns when the verification of the TLS certificate of the provider authentication service is skipped,
+// and checks that the CA bundle is valid and not set together with the insecure mode
+func (p *peeringResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan peeringResourceModel
+	if diags := req.Plan.Get(ctx, &plan); diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTLSVerification(path.Root("insecure_skip_tls_verify"), plan.InsecureSkipTLSVerify)...)
+
+	if plan.CABundle.IsNull() || plan.CABundle.IsUnknown() {
+		return
+	}
+
+	if plan.InsecureSkipTLSVerify.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_bundle"),
+			"Conflicting TLS Settings",
+			"The CA bundle cannot be set when the TLS verification is skipped.",
+		)
+	} else if !x509.NewCertPool().AppendCertsFromPEM([]byte(plan.CABundle.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_bundle"),
+			"Invalid CA Bundle",
+			"The CA bundle does not contain any PEM-encoded certificate.",
+		)
+	}
+}
+
 // Creation of Peering Resource to execute peering between two clusters using auth parameters provided by Generate Resource
 // This resource will reproduce the same effect and outputs of "liqoctl peer out-of-band" command
 func (p *peeringResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -96,74 +290,72 @@ func (p *peeringResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	overrides := &clientcmd.ConfigOverrides{}
-	loader := &clientcmd.ClientConfigLoadingRules{}
+	CRClient, KubeClient, err := p.data.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
 
-	configPaths := []string{}
+	clusterToken, err := plan.clusterToken(ctx, CRClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
 
-	if !p.config.KUBERNETES.KUBE_CONFIG_PATH.IsNull() {
-		configPaths = []string{p.config.KUBERNETES.KUBE_CONFIG_PATH.ValueString()}
-	} else if len(p.config.KUBERNETES.KUBE_CONFIG_PATHS) > 0 {
-		for _, configPath := range p.config.KUBERNETES.KUBE_CONFIG_PATHS {
-			configPaths = append(configPaths, configPath.ValueString())
-		}
-	} else if v := os.Getenv("KUBE_CONFIG_PATHS"); v != "" {
-		configPaths = filepath.SplitList(v)
-	}
-
-	if len(configPaths) > 0 {
-		expandedPaths := []string{}
-		for _, p := range configPaths {
-			path, err := homedir.Expand(p)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Unable to Create Resource",
-					err.Error(),
-				)
-				return
-			}
-			expandedPaths = append(expandedPaths, path)
-		}
+	fc, err := peerOutOfBand(ctx, CRClient, KubeClient, outOfBandPeering{
+		ClusterID:     plan.ClusterID.ValueString(),
+		ClusterName:   plan.ClusterName.ValueString(),
+		AuthURL:       plan.ClusterAuthURL.ValueString(),
+		Token:         clusterToken,
+		LiqoNamespace: plan.LiqoNamespace.ValueString(),
 
-		if len(expandedPaths) == 1 {
-			loader.ExplicitPath = expandedPaths[0]
-		} else {
-			loader.Precedence = expandedPaths
-		}
+		ProxyURL:               plan.ClusterProxyURL.ValueString(),
+		IncomingPeeringEnabled: discoveryv1alpha1.PeeringEnabledType(plan.IncomingPeeringEnabled.ValueString()),
+		InsecureSkipTLSVerify:  plan.InsecureSkipTLSVerify.ValueBool(),
+		CABundle:               plan.CABundle.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
 
-		ctxNotOk := p.config.KUBERNETES.KUBE_CTX.IsNull()
-		authInfoNotOk := p.config.KUBERNETES.KUBE_CTX_AUTH_INFO.IsNull()
-		clusterNotOk := p.config.KUBERNETES.KUBE_CTX_CLUSTER.IsNull()
+	if err := plan.setForeignClusterStatus(ctx, CRClient, fc); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			err.Error(),
+		)
+		return
+	}
 
-		if ctxNotOk || authInfoNotOk || clusterNotOk {
-			if ctxNotOk {
-				overrides.CurrentContext = p.config.KUBERNETES.KUBE_CTX.ValueString()
-			}
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-			overrides.Context = clientcmdapi.Context{}
-			if authInfoNotOk {
-				overrides.Context.AuthInfo = p.config.KUBERNETES.KUBE_CTX_AUTH_INFO.ValueString()
-			}
-			if clusterNotOk {
-				overrides.Context.Cluster = p.config.KUBERNETES.KUBE_CTX_CLUSTER.ValueString()
-			}
+	// The state is stored before waiting, so that a peering which fails to be established is tainted rather than lost
+	if plan.WaitForEstablished.ValueBool() {
+		if err := waitForEstablished(ctx, CRClient, plan.ClusterID.ValueString(), plan.Timeouts.CreateTimeout()); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Create Resource",
+				err.Error(),
+			)
+			return
 		}
-	}
 
-	if !p.config.KUBERNETES.KUBE_INSECURE.IsNull() {
-		overrides.ClusterInfo.InsecureSkipTLSVerify = !p.config.KUBERNETES.KUBE_INSECURE.ValueBool()
-	}
-	if !p.config.KUBERNETES.KUBE_CLUSTER_CA_CERT_DATA.IsNull() {
-		overrides.ClusterInfo.CertificateAuthorityData = bytes.NewBufferString(p.config.KUBERNETES.KUBE_CLUSTER_CA_CERT_DATA.ValueString()).Bytes()
-	}
-	if !p.config.KUBERNETES.KUBE_CLIENT_CERT_DATA.IsNull() {
-		overrides.AuthInfo.ClientCertificateData = bytes.NewBufferString(p.config.KUBERNETES.KUBE_CLIENT_CERT_DATA.ValueString()).Bytes()
-	}
-	if !p.config.KUBERNETES.KUBE_HOST.IsNull() {
-		hasCA := len(overrides.ClusterInfo.CertificateAuthorityData) != 0
-		hasCert := len(overrides.AuthInfo.ClientCertificateData) != 0
-		defaultTLS := hasCA || hasCert || overrides.ClusterInfo.InsecureSkipTLSVerify
-		host, _, err := rest.DefaultServerURL(p.config.KUBERNETES.KUBE_HOST.ValueString(), "", apimachineryschema.GroupVersion{}, defaultTLS)
+		fc, err = foreigncluster.GetForeignClusterByID(ctx, CRClient, plan.ClusterID.ValueString())
+		if err == nil {
+			err = plan.setForeignClusterStatus(ctx, CRClient, fc)
+		}
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to Create Resource",
@@ -172,366 +364,650 @@ func (p *peeringResource) Create(ctx context.Context, req resource.CreateRequest
 			return
 		}
 
-		overrides.ClusterInfo.Server = host.String()
-	}
-	if !p.config.KUBERNETES.KUBE_USER.IsNull() {
-		overrides.AuthInfo.Username = p.config.KUBERNETES.KUBE_USER.ValueString()
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
 	}
-	if !p.config.KUBERNETES.KUBE_PASSWORD.IsNull() {
-		overrides.AuthInfo.Password = p.config.KUBERNETES.KUBE_PASSWORD.ValueString()
+}
+
+// Read refreshes the Peering Resource from the ForeignCluster associated with the provider cluster,
+// the resource is removed from the state if the ForeignCluster does not exist anymore or the outgoing peering has been disabled
+func (p *peeringResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state peeringResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if !p.config.KUBERNETES.KUBE_CLIENT_KEY_DATA.IsNull() {
-		overrides.AuthInfo.ClientKeyData = bytes.NewBufferString(p.config.KUBERNETES.KUBE_CLIENT_KEY_DATA.ValueString()).Bytes()
+
+	CRClient, _, err := p.data.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			err.Error(),
+		)
+		return
 	}
-	if !p.config.KUBERNETES.KUBE_TOKEN.IsNull() {
-		overrides.AuthInfo.Token = p.config.KUBERNETES.KUBE_TOKEN.ValueString()
+
+	fc, err := foreigncluster.GetForeignClusterByID(ctx, CRClient, state.ClusterID.ValueString())
+	if kerrors.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			err.Error(),
+		)
+		return
 	}
 
-	if !p.config.KUBERNETES.KUBE_PROXY_URL.IsNull() {
-		overrides.ClusterDefaults.ProxyURL = p.config.KUBERNETES.KUBE_PROXY_URL.ValueString()
+	if fc.Spec.OutgoingPeeringEnabled == discoveryv1alpha1.PeeringEnabledNo {
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
-	if len(p.config.KUBERNETES.KUBE_EXEC) > 0 {
-		exec := &clientcmdapi.ExecConfig{}
-		exec.InteractiveMode = clientcmdapi.IfAvailableExecInteractiveMode
-		exec.APIVersion = p.config.KUBERNETES.KUBE_EXEC[0].API_VERSION.ValueString()
-		exec.Command = p.config.KUBERNETES.KUBE_EXEC[0].COMMAND.ValueString()
-		for _, arg := range p.config.KUBERNETES.KUBE_EXEC[0].ARGS {
-			exec.Args = append(exec.Args, arg.ValueString())
-		}
+	if fc.Spec.ClusterIdentity.ClusterName != "" {
+		state.ClusterName = types.StringValue(fc.Spec.ClusterIdentity.ClusterName)
+	}
+	state.ClusterAuthURL = types.StringValue(fc.Spec.ForeignAuthURL)
+	state.setForeignClusterSettings(fc)
+	if err := state.setForeignClusterStatus(ctx, CRClient, fc); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			err.Error(),
+		)
+		return
+	}
 
-		for kk, vv := range p.config.KUBERNETES.KUBE_EXEC[0].ENV.Elements() {
-			exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: kk, Value: vv.String()})
-		}
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
 
-		overrides.AuthInfo.Exec = exec
+// Update of Peering Resource stores again the authentication token and patches the authentication settings of the existing ForeignCluster,
+// so that the token can be rotated and the incoming peering toggled without tearing down the peering
+func (p *peeringResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan peeringResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	clientCfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
-	if clientCfg == nil {
+	CRClient, KubeClient, err := p.data.Clients()
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			"Unable to Create Resource while creating clientCfg",
+			"Unable to Update Resource",
+			err.Error(),
 		)
 		return
 	}
 
-	var restCfg *rest.Config
-
-	restCfg, err := clientCfg.ClientConfig()
+	clusterToken, err := plan.clusterToken(ctx, CRClient)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
+			"Unable to Update Resource",
 			err.Error(),
 		)
 		return
 	}
 
-	var CRClient client.Client
-
-	CRClient, err = client.New(restCfg, client.Options{Scheme: scheme.Scheme})
+	err = authenticationtokenutils.StoreInSecret(ctx, KubeClient, plan.ClusterID.ValueString(), clusterToken, plan.LiqoNamespace.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
+			"Unable to Update Resource",
 			err.Error(),
 		)
 		return
 	}
 
-	KubeClient, err := kubernetes.NewForConfig(restCfg)
+	if !plan.InsecureSkipTLSVerify.ValueBool() && !plan.CABundle.IsNull() {
+		err := verifyAuthService(ctx, plan.ClusterAuthURL.ValueString(), plan.ClusterID.ValueString(), plan.CABundle.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Update Resource",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	fc, err := foreigncluster.GetForeignClusterByID(ctx, CRClient, plan.ClusterID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
+			"Unable to Update Resource",
 			err.Error(),
 		)
 		return
 	}
 
-	clusterIdentity, err := utils.GetClusterIdentityWithControllerClient(ctx, CRClient, plan.LiqoNamespace.ValueString())
-	if err != nil {
+	original := fc.DeepCopy()
+	fc.Spec.ForeignAuthURL = plan.ClusterAuthURL.ValueString()
+	fc.Spec.ForeignProxyURL = plan.ClusterProxyURL.ValueString()
+	fc.Spec.IncomingPeeringEnabled = discoveryv1alpha1.PeeringEnabledType(plan.IncomingPeeringEnabled.ValueString())
+	fc.Spec.InsecureSkipTLSVerify = pointer.BoolPtr(plan.InsecureSkipTLSVerify.ValueBool())
+	if err := CRClient.Patch(ctx, fc, client.MergeFrom(original)); err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
+			"Unable to Update Resource",
 			err.Error(),
 		)
 		return
 	}
 
-	if clusterIdentity.ClusterID == plan.ClusterID.ValueString() {
+	if err := plan.setForeignClusterStatus(ctx, CRClient, fc); err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			"The Cluster ID of the remote cluster is the same of that of the local cluster",
+			"Unable to Update Resource",
+			err.Error(),
 		)
 		return
 	}
 
-	err = authenticationtokenutils.StoreInSecret(ctx, KubeClient, plan.ClusterID.ValueString(), plan.ClusterToken.ValueString(), plan.LiqoNamespace.ValueString())
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.WaitForEstablished.ValueBool() {
+		if err := waitForEstablished(ctx, CRClient, plan.ClusterID.ValueString(), plan.Timeouts.UpdateTimeout()); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Update Resource",
+				err.Error(),
+			)
+			return
+		}
+
+		fc, err = foreigncluster.GetForeignClusterByID(ctx, CRClient, plan.ClusterID.ValueString())
+		if err == nil {
+			err = plan.setForeignClusterStatus(ctx, CRClient, fc)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Update Resource",
+				err.Error(),
+			)
+			return
+		}
+
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+	}
+}
+
+// Delete of Peering Resource reproduces "liqoctl unpeer out-of-band": the outgoing peering is disabled and, once it has been torn down,
+// the ForeignCluster and the authentication token Secret are removed unless an incoming peering from the provider cluster is still active
+func (p *peeringResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state peeringResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	CRClient, _, err := p.data.Clients()
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
+			"Unable to Delete Resource",
 			err.Error(),
 		)
 		return
 	}
 
-	fc, err := foreigncluster.GetForeignClusterByID(ctx, CRClient, plan.ClusterID.ValueString())
-	if kerrors.IsNotFound(err) {
-		fc = &discoveryv1alpha1.ForeignCluster{ObjectMeta: metav1.ObjectMeta{Name: plan.ClusterName.ValueString(),
-			Labels: map[string]string{discovery.ClusterIDLabel: plan.ClusterID.ValueString()}}}
-	} else if err != nil {
+	removed, err := unpeerOutOfBand(ctx, CRClient, state.ClusterID.ValueString(), state.LiqoNamespace.ValueString(), state.Timeouts.DeleteTimeout())
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
+			"Unable to Delete Resource",
 			err.Error(),
 		)
 		return
 	}
 
-	_, err = controllerutil.CreateOrUpdate(ctx, CRClient, fc, func() error {
-		if fc.Spec.PeeringType != discoveryv1alpha1.PeeringTypeUnknown && fc.Spec.PeeringType != discoveryv1alpha1.PeeringTypeOutOfBand {
-			return fmt.Errorf("a peering of type %s already exists towards remote cluster %q, cannot be changed to %s",
-				fc.Spec.PeeringType, plan.ClusterName.ValueString(), discoveryv1alpha1.PeeringTypeOutOfBand)
-		}
+	if !removed {
+		resp.Diagnostics.AddWarning(
+			"ForeignCluster Not Removed",
+			fmt.Sprintf("The ForeignCluster of the cluster %q and its authentication token were not removed, as an incoming peering is still active. "+
+				"Disable it on the provider cluster to complete the removal.", state.ClusterID.ValueString()),
+		)
+	}
+}
 
-		fc.Spec.PeeringType = discoveryv1alpha1.PeeringTypeOutOfBand
-		fc.Spec.ClusterIdentity.ClusterID = plan.ClusterID.ValueString()
-		if fc.Spec.ClusterIdentity.ClusterName == "" {
-			fc.Spec.ClusterIdentity.ClusterName = plan.ClusterName.ValueString()
-		}
+// ImportState adopts a peering established out of Terraform (e.g. with "liqoctl peer out-of-band"),
+// the identifier is the provider cluster ID, optionally prefixed by the Liqo namespace as "<liqo_namespace>/<cluster_id>".
+// The authentication token is read from the Secret where Liqo stores it, so that the plan is empty after the import
+func (p *peeringResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	liqoNamespace, clusterID := "liqo", req.ID
+	if i := strings.LastIndex(req.ID, "/"); i >= 0 {
+		liqoNamespace, clusterID = req.ID[:i], req.ID[i+1:]
+	}
+	if liqoNamespace == "" || clusterID == "" {
+		resp.Diagnostics.AddError(
+			"Unable to Import Resource",
+			fmt.Sprintf("Expected import identifier with format <cluster_id> or <liqo_namespace>/<cluster_id>, got %q.", req.ID),
+		)
+		return
+	}
 
-		fc.Spec.ForeignAuthURL = plan.ClusterAuthURL.ValueString()
-		fc.Spec.ForeignProxyURL = ""
-		fc.Spec.OutgoingPeeringEnabled = discoveryv1alpha1.PeeringEnabledYes
-		if fc.Spec.IncomingPeeringEnabled == "" {
-			fc.Spec.IncomingPeeringEnabled = discoveryv1alpha1.PeeringEnabledAuto
-		}
-		if fc.Spec.InsecureSkipTLSVerify == nil {
-			fc.Spec.InsecureSkipTLSVerify = pointer.BoolPtr(true)
-		}
-		return nil
-	})
+	CRClient, _, err := p.data.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Import Resource",
+			err.Error(),
+		)
+		return
+	}
 
+	fc, err := foreigncluster.GetForeignClusterByID(ctx, CRClient, clusterID)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
+			"Unable to Import Resource",
 			err.Error(),
 		)
+		return
+	}
 
+	token, err := authenticationtokenutils.GetAuthToken(ctx, clusterID, CRClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Import Resource",
+			err.Error(),
+		)
 		return
 	}
 
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	state := peeringResourceModel{
+		ClusterID:          types.StringValue(clusterID),
+		ClusterName:        types.StringValue(fc.Spec.ClusterIdentity.ClusterName),
+		ClusterAuthURL:     types.StringValue(fc.Spec.ForeignAuthURL),
+		ClusterToken:       types.StringNull(),
+		LiqoNamespace:      types.StringValue(liqoNamespace),
+		WaitForEstablished: types.BoolValue(false),
+	}
+	if token != "" {
+		state.ClusterToken = types.StringValue(token)
+	}
+	state.setForeignClusterSettings(fc)
+	if err := state.setForeignClusterStatus(ctx, CRClient, fc); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Import Resource",
+			err.Error(),
+		)
 		return
 	}
-}
 
-func (p *peeringResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state peeringResourceModel
-	diags := req.State.Get(ctx, &state)
+	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+}
 
-	diags = resp.State.Set(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+// Configure method to obtain kubernetes Clients provided by provider
+func (p *peeringResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
 		return
 	}
+
+	p.data = req.ProviderData.(*liqoProviderData)
 }
 
-func (p *peeringResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Unable to Update Resource",
-		"Update is not supported/permitted yet.",
-	)
+// outOfBandPeering describes the out-of-band peering towards a remote cluster
+type outOfBandPeering struct {
+	ClusterID     string
+	ClusterName   string
+	AuthURL       string
+	Token         string
+	LiqoNamespace string
+
+	// IncomingPeeringEnabled is left untouched on an existing ForeignCluster when empty
+	ProxyURL               string
+	IncomingPeeringEnabled discoveryv1alpha1.PeeringEnabledType
+
+	// The authentication service is verified with CABundle before peering, unless InsecureSkipTLSVerify is set
+	InsecureSkipTLSVerify bool
+	CABundle              string
 }
 
-func (p *peeringResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+// peerOutOfBand reproduces "liqoctl peer out-of-band": the authentication token of the remote cluster is stored
+// and the ForeignCluster towards it is created, or updated if it already exists
+func peerOutOfBand(ctx context.Context, CRClient client.Client, KubeClient kubernetes.Interface, peering outOfBandPeering) (*discoveryv1alpha1.ForeignCluster, error) {
+	clusterIdentity, err := utils.GetClusterIdentityWithControllerClient(ctx, CRClient, peering.LiqoNamespace)
+	if err != nil {
+		return nil, err
+	}
 
-	var data peeringResourceModel
+	if clusterIdentity.ClusterID == peering.ClusterID {
+		return nil, fmt.Errorf("The Cluster ID of the remote cluster is the same of that of the local cluster")
+	}
 
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if !peering.InsecureSkipTLSVerify && peering.CABundle != "" {
+		if err := verifyAuthService(ctx, peering.AuthURL, peering.ClusterID, peering.CABundle); err != nil {
+			return nil, err
+		}
+	}
 
-	overrides := &clientcmd.ConfigOverrides{}
-	loader := &clientcmd.ClientConfigLoadingRules{}
+	err = authenticationtokenutils.StoreInSecret(ctx, KubeClient, peering.ClusterID, peering.Token, peering.LiqoNamespace)
+	if err != nil {
+		return nil, err
+	}
 
-	configPaths := []string{}
+	fc, err := foreigncluster.GetForeignClusterByID(ctx, CRClient, peering.ClusterID)
+	if kerrors.IsNotFound(err) {
+		fc = &discoveryv1alpha1.ForeignCluster{ObjectMeta: metav1.ObjectMeta{Name: peering.ClusterName,
+			Labels: map[string]string{discovery.ClusterIDLabel: peering.ClusterID}}}
+	} else if err != nil {
+		return nil, err
+	}
 
-	if !p.config.KUBERNETES.KUBE_CONFIG_PATH.IsNull() {
-		configPaths = []string{p.config.KUBERNETES.KUBE_CONFIG_PATH.ValueString()}
-	} else if len(p.config.KUBERNETES.KUBE_CONFIG_PATHS) > 0 {
-		for _, configPath := range p.config.KUBERNETES.KUBE_CONFIG_PATHS {
-			configPaths = append(configPaths, configPath.ValueString())
-		}
-	} else if v := os.Getenv("KUBE_CONFIG_PATHS"); v != "" {
-		configPaths = filepath.SplitList(v)
-	}
-
-	if len(configPaths) > 0 {
-		expandedPaths := []string{}
-		for _, p := range configPaths {
-			path, err := homedir.Expand(p)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Unable to Create Resource",
-					err.Error(),
-				)
-				return
-			}
-			expandedPaths = append(expandedPaths, path)
+	_, err = controllerutil.CreateOrUpdate(ctx, CRClient, fc, func() error {
+		if fc.Spec.PeeringType != discoveryv1alpha1.PeeringTypeUnknown && fc.Spec.PeeringType != discoveryv1alpha1.PeeringTypeOutOfBand {
+			return fmt.Errorf("a peering of type %s already exists towards remote cluster %q, cannot be changed to %s",
+				fc.Spec.PeeringType, peering.ClusterName, discoveryv1alpha1.PeeringTypeOutOfBand)
 		}
 
-		if len(expandedPaths) == 1 {
-			loader.ExplicitPath = expandedPaths[0]
-		} else {
-			loader.Precedence = expandedPaths
+		fc.Spec.PeeringType = discoveryv1alpha1.PeeringTypeOutOfBand
+		fc.Spec.ClusterIdentity.ClusterID = peering.ClusterID
+		if fc.Spec.ClusterIdentity.ClusterName == "" {
+			fc.Spec.ClusterIdentity.ClusterName = peering.ClusterName
 		}
 
-		ctxNotOk := p.config.KUBERNETES.KUBE_CTX.IsNull()
-		authInfoNotOk := p.config.KUBERNETES.KUBE_CTX_AUTH_INFO.IsNull()
-		clusterNotOk := p.config.KUBERNETES.KUBE_CTX_CLUSTER.IsNull()
+		fc.Spec.ForeignAuthURL = peering.AuthURL
+		fc.Spec.ForeignProxyURL = peering.ProxyURL
+		fc.Spec.OutgoingPeeringEnabled = discoveryv1alpha1.PeeringEnabledYes
+		if peering.IncomingPeeringEnabled != "" {
+			fc.Spec.IncomingPeeringEnabled = peering.IncomingPeeringEnabled
+		} else if fc.Spec.IncomingPeeringEnabled == "" {
+			fc.Spec.IncomingPeeringEnabled = discoveryv1alpha1.PeeringEnabledAuto
+		}
+		fc.Spec.InsecureSkipTLSVerify = pointer.BoolPtr(peering.InsecureSkipTLSVerify)
+		return nil
+	})
 
-		if ctxNotOk || authInfoNotOk || clusterNotOk {
-			if ctxNotOk {
-				overrides.CurrentContext = p.config.KUBERNETES.KUBE_CTX.ValueString()
-			}
+	return fc, err
+}
 
-			overrides.Context = clientcmdapi.Context{}
-			if authInfoNotOk {
-				overrides.Context.AuthInfo = p.config.KUBERNETES.KUBE_CTX_AUTH_INFO.ValueString()
-			}
-			if clusterNotOk {
-				overrides.Context.Cluster = p.config.KUBERNETES.KUBE_CTX_CLUSTER.ValueString()
-			}
-		}
+// verifyAuthService checks that the authentication service at authURL belongs to the given cluster
+// and exposes a certificate signed by one of the CAs in caBundle
+func verifyAuthService(ctx context.Context, authURL, clusterID, caBundle string) error {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(caBundle)) {
+		return fmt.Errorf("the CA bundle does not contain any PEM-encoded certificate")
 	}
 
-	if !p.config.KUBERNETES.KUBE_INSECURE.IsNull() {
-		overrides.ClusterInfo.InsecureSkipTLSVerify = !p.config.KUBERNETES.KUBE_INSECURE.ValueBool()
+	transport := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}}
+	defer transport.CloseIdleConnections()
+
+	info, err := discoveryutils.GetClusterInfo(ctx, transport, authURL)
+	if err != nil {
+		return fmt.Errorf("unable to verify the authentication service %s with the CA bundle: %w", authURL, err)
 	}
-	if !p.config.KUBERNETES.KUBE_CLUSTER_CA_CERT_DATA.IsNull() {
-		overrides.ClusterInfo.CertificateAuthorityData = bytes.NewBufferString(p.config.KUBERNETES.KUBE_CLUSTER_CA_CERT_DATA.ValueString()).Bytes()
+	if info.ClusterID != clusterID {
+		return fmt.Errorf("the authentication service %s belongs to the cluster %q, expected %q", authURL, info.ClusterID, clusterID)
 	}
-	if !p.config.KUBERNETES.KUBE_CLIENT_CERT_DATA.IsNull() {
-		overrides.AuthInfo.ClientCertificateData = bytes.NewBufferString(p.config.KUBERNETES.KUBE_CLIENT_CERT_DATA.ValueString()).Bytes()
+	return nil
+}
+
+// checkTLSVerification warns when the verification of the TLS certificate of the authentication service is skipped
+func checkTLSVerification(attribute path.Path, insecureSkipTLSVerify types.Bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if insecureSkipTLSVerify.ValueBool() {
+		diags.AddAttributeWarning(
+			attribute,
+			"Insecure Peering",
+			"The TLS certificate of the remote authentication service will not be verified, "+
+				"hence the authentication token may be sent to an impersonating service.",
+		)
 	}
-	if !p.config.KUBERNETES.KUBE_HOST.IsNull() {
-		hasCA := len(overrides.ClusterInfo.CertificateAuthorityData) != 0
-		hasCert := len(overrides.AuthInfo.ClientCertificateData) != 0
-		defaultTLS := hasCA || hasCert || overrides.ClusterInfo.InsecureSkipTLSVerify
-		host, _, err := rest.DefaultServerURL(p.config.KUBERNETES.KUBE_HOST.ValueString(), "", apimachineryschema.GroupVersion{}, defaultTLS)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Create Resource",
-				err.Error(),
-			)
-			return
-		}
+	return diags
+}
 
-		overrides.ClusterInfo.Server = host.String()
+// unpeerOutOfBand reproduces "liqoctl unpeer out-of-band": the outgoing peering is disabled and, once it has been torn down,
+// the ForeignCluster and the authentication token Secret are removed. They are kept, and false is returned,
+// when an incoming peering from the remote cluster is still active
+func unpeerOutOfBand(ctx context.Context, CRClient client.Client, clusterID, liqoNamespace string, timeout time.Duration) (bool, error) {
+	fc, err := foreigncluster.GetForeignClusterByID(ctx, CRClient, clusterID)
+	if kerrors.IsNotFound(err) {
+		return true, deleteAuthTokenSecret(ctx, CRClient, clusterID, liqoNamespace)
+	} else if err != nil {
+		return false, err
 	}
-	if !p.config.KUBERNETES.KUBE_USER.IsNull() {
-		overrides.AuthInfo.Username = p.config.KUBERNETES.KUBE_USER.ValueString()
+
+	if fc.Spec.PeeringType != discoveryv1alpha1.PeeringTypeOutOfBand {
+		return false, fmt.Errorf("the peering type towards the cluster %q is %s, expected %s",
+			clusterID, fc.Spec.PeeringType, discoveryv1alpha1.PeeringTypeOutOfBand)
 	}
-	if !p.config.KUBERNETES.KUBE_PASSWORD.IsNull() {
-		overrides.AuthInfo.Password = p.config.KUBERNETES.KUBE_PASSWORD.ValueString()
+
+	original := fc.DeepCopy()
+	fc.Spec.OutgoingPeeringEnabled = discoveryv1alpha1.PeeringEnabledNo
+	if err := CRClient.Patch(ctx, fc, client.MergeFrom(original)); err != nil {
+		return false, err
 	}
-	if !p.config.KUBERNETES.KUBE_CLIENT_KEY_DATA.IsNull() {
-		overrides.AuthInfo.ClientKeyData = bytes.NewBufferString(p.config.KUBERNETES.KUBE_CLIENT_KEY_DATA.ValueString()).Bytes()
+
+	if err := waitForUnpeered(ctx, CRClient, clusterID, timeout); err != nil {
+		return false, err
 	}
-	if !p.config.KUBERNETES.KUBE_TOKEN.IsNull() {
-		overrides.AuthInfo.Token = p.config.KUBERNETES.KUBE_TOKEN.ValueString()
+
+	fc, err = foreigncluster.GetForeignClusterByID(ctx, CRClient, clusterID)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return false, err
 	}
 
-	if !p.config.KUBERNETES.KUBE_PROXY_URL.IsNull() {
-		overrides.ClusterDefaults.ProxyURL = p.config.KUBERNETES.KUBE_PROXY_URL.ValueString()
+	if err == nil {
+		if peeringconditionsutils.GetStatus(fc, discoveryv1alpha1.IncomingPeeringCondition) != discoveryv1alpha1.PeeringConditionStatusNone {
+			return false, nil
+		}
+
+		if err := CRClient.Delete(ctx, fc); client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
 	}
 
-	if len(p.config.KUBERNETES.KUBE_EXEC) > 0 {
-		exec := &clientcmdapi.ExecConfig{}
-		exec.InteractiveMode = clientcmdapi.IfAvailableExecInteractiveMode
-		exec.APIVersion = p.config.KUBERNETES.KUBE_EXEC[0].API_VERSION.ValueString()
-		exec.Command = p.config.KUBERNETES.KUBE_EXEC[0].COMMAND.ValueString()
-		for _, arg := range p.config.KUBERNETES.KUBE_EXEC[0].ARGS {
-			exec.Args = append(exec.Args, arg.ValueString())
+	return true, deleteAuthTokenSecret(ctx, CRClient, clusterID, liqoNamespace)
+}
+
+// waitForEstablished polls the ForeignCluster of the given cluster until the peering is established and the virtual node is ready,
+// or the timeout expires. The returned error reports the message of the condition which failed or was still pending
+func waitForEstablished(ctx context.Context, CRClient client.Client, clusterID string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pending := "the ForeignCluster has not been found"
+
+	err := wait.PollImmediateUntilWithContext(ctx, time.Second, func(ctx context.Context) (bool, error) {
+		fc, err := foreigncluster.GetForeignClusterByID(ctx, CRClient, clusterID)
+		if err != nil {
+			return false, client.IgnoreNotFound(err)
 		}
 
-		for kk, vv := range p.config.KUBERNETES.KUBE_EXEC[0].ENV.Elements() {
-			exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: kk, Value: vv.String()})
+		switch peeringconditionsutils.GetStatus(fc, discoveryv1alpha1.ProcessForeignClusterStatusCondition) {
+		case discoveryv1alpha1.PeeringConditionStatusSuccess:
+		case discoveryv1alpha1.PeeringConditionStatusError:
+			return false, fmt.Errorf("the ForeignCluster cannot be processed: %s",
+				peeringconditionsutils.GetMessage(fc, discoveryv1alpha1.ProcessForeignClusterStatusCondition))
+		default:
+			pending = fmt.Sprintf("the ForeignCluster has not been processed yet: %s",
+				peeringconditionsutils.GetMessage(fc, discoveryv1alpha1.ProcessForeignClusterStatusCondition))
+			return false, nil
 		}
 
-		overrides.AuthInfo.Exec = exec
-	}
+		conditions := []discoveryv1alpha1.PeeringConditionType{
+			discoveryv1alpha1.AuthenticationStatusCondition,
+			discoveryv1alpha1.OutgoingPeeringCondition,
+		}
+		if foreigncluster.IsNetworkingEnabled(fc) {
+			conditions = append(conditions, discoveryv1alpha1.NetworkStatusCondition)
+		}
 
-	clientCfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
-	if clientCfg == nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			"Unable to Create Resource while creating clientCfg",
-		)
-		return
-	}
+		for _, condition := range conditions {
+			switch status := peeringconditionsutils.GetStatus(fc, condition); status {
+			case discoveryv1alpha1.PeeringConditionStatusEstablished:
+			case discoveryv1alpha1.PeeringConditionStatusError, discoveryv1alpha1.PeeringConditionStatusDenied,
+				discoveryv1alpha1.PeeringConditionStatusEmptyDenied:
+				return false, fmt.Errorf("the %s condition is %s: %s", condition, status, peeringconditionsutils.GetMessage(fc, condition))
+			default:
+				pending = fmt.Sprintf("the %s condition is %s: %s", condition, status, peeringconditionsutils.GetMessage(fc, condition))
+				return false, nil
+			}
+		}
+
+		node, err := getters.GetNodeByClusterID(ctx, CRClient, &fc.Spec.ClusterIdentity)
+		if err != nil {
+			pending = "the virtual node has not been created yet"
+			return false, client.IgnoreNotFound(err)
+		}
 
-	var restCfg *rest.Config
+		pending = fmt.Sprintf("the virtual node %q is not ready", node.Name)
+		return utils.IsNodeReady(node), nil
+	})
 
-	restCfg, err := clientCfg.ClientConfig()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			err.Error(),
-		)
-		return
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return fmt.Errorf("timed out after %s waiting for the peering to be established, %s", timeout, pending)
 	}
+	return err
+}
 
-	var CRClient client.Client
+type peeringResourceModel struct {
+	ClusterID             types.String `tfsdk:"cluster_id"`
+	ClusterName           types.String `tfsdk:"cluster_name"`
+	ClusterAuthURL        types.String `tfsdk:"cluster_authurl"`
+	ClusterToken          types.String `tfsdk:"cluster_token"`
+	ClusterTokenSecretRef *secretRef   `tfsdk:"cluster_token_secret_ref"`
+	LiqoNamespace         types.String `tfsdk:"liqo_namespace"`
+
+	ClusterProxyURL        types.String `tfsdk:"cluster_proxy_url"`
+	IncomingPeeringEnabled types.String `tfsdk:"incoming_peering_enabled"`
+	CABundle               types.String `tfsdk:"ca_bundle"`
+	InsecureSkipTLSVerify  types.Bool   `tfsdk:"insecure_skip_tls_verify"`
+
+	WaitForEstablished types.Bool `tfsdk:"wait_for_established"`
+	Timeouts           *timeouts  `tfsdk:"timeouts"`
+
+	PeeringType            types.String `tfsdk:"peering_type"`
+	OutgoingPeeringEnabled types.String `tfsdk:"outgoing_peering_enabled"`
+
+	AuthenticationStatus  types.String `tfsdk:"authentication_status"`
+	IncomingPeeringStatus types.String `tfsdk:"incoming_peering_status"`
+	OutgoingPeeringStatus types.String `tfsdk:"outgoing_peering_status"`
+	NetworkStatus         types.String `tfsdk:"network_status"`
+	LocalTenantNamespace  types.String `tfsdk:"local_tenant_namespace"`
+	RemoteTenantNamespace types.String `tfsdk:"remote_tenant_namespace"`
+	VirtualNodeName       types.String `tfsdk:"virtual_node_name"`
+}
 
-	CRClient, err = client.New(restCfg, client.Options{Scheme: scheme.Scheme})
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			err.Error(),
-		)
-		return
-	}
+type secretRef struct {
+	Name      types.String `tfsdk:"name"`
+	Namespace types.String `tfsdk:"namespace"`
+	Key       types.String `tfsdk:"key"`
+}
 
-	if CRClient == nil {
-		return
-	}
+// waitForUnpeered polls the ForeignCluster of the given cluster until the outgoing peering has been torn down,
+// that is the outgoing ResourceRequest and the virtual node have been removed, or the timeout expires
+func waitForUnpeered(ctx context.Context, CRClient client.Client, clusterID string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	var foreignCluster discoveryv1alpha1.ForeignCluster
-	if err := CRClient.Get(ctx, kubeTypes.NamespacedName{Name: data.ClusterName.ValueString()}, &foreignCluster); err != nil {
-		return
+	pending := ""
+
+	err := wait.PollImmediateUntilWithContext(ctx, time.Second, func(ctx context.Context) (bool, error) {
+		fc, err := foreigncluster.GetForeignClusterByID(ctx, CRClient, clusterID)
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		if fc.Status.TenantNamespace.Local != "" {
+			var resourceRequests discoveryv1alpha1.ResourceRequestList
+			if err := CRClient.List(ctx, &resourceRequests, client.InNamespace(fc.Status.TenantNamespace.Local),
+				client.MatchingLabels{consts.ReplicationRequestedLabel: "true", consts.ReplicationDestinationLabel: clusterID}); err != nil {
+				return false, err
+			}
+			if len(resourceRequests.Items) > 0 {
+				pending = fmt.Sprintf("the ResourceRequest %q has not been removed yet", resourceRequests.Items[0].Name)
+				return false, nil
+			}
+		}
+
+		node, err := getters.GetNodeByClusterID(ctx, CRClient, &fc.Spec.ClusterIdentity)
+		if err == nil {
+			pending = fmt.Sprintf("the virtual node %q has not been removed yet", node.Name)
+			return false, nil
+		} else if !kerrors.IsNotFound(err) {
+			return false, err
+		}
+
+		if !foreigncluster.IsOutgoingPeeringNone(fc) {
+			pending = fmt.Sprintf("the %s condition is %s", discoveryv1alpha1.OutgoingPeeringCondition,
+				peeringconditionsutils.GetStatus(fc, discoveryv1alpha1.OutgoingPeeringCondition))
+			return false, nil
+		}
+
+		return true, nil
+	})
+
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return fmt.Errorf("timed out after %s waiting for the peering to be torn down, %s", timeout, pending)
 	}
+	return err
+}
 
-	if foreignCluster.Spec.PeeringType != discoveryv1alpha1.PeeringTypeOutOfBand {
-		return
+// deleteAuthTokenSecret removes the Secret storing the authentication token of the given cluster
+func deleteAuthTokenSecret(ctx context.Context, CRClient client.Client, clusterID, liqoNamespace string) error {
+	return CRClient.DeleteAllOf(ctx, &corev1.Secret{}, client.InNamespace(liqoNamespace),
+		client.MatchingLabels{discovery.ClusterIDLabel: clusterID, discovery.AuthTokenLabel: ""})
+}
+
+// clusterToken returns the authentication token of the provider cluster, either set in the configuration or read from the referenced Secret
+func (m *peeringResourceModel) clusterToken(ctx context.Context, CRClient client.Client) (string, error) {
+	if m.ClusterTokenSecretRef == nil {
+		return m.ClusterToken.ValueString(), nil
 	}
 
-	foreignCluster.Spec.OutgoingPeeringEnabled = discoveryv1alpha1.PeeringEnabledNo
-	if err := CRClient.Update(ctx, &foreignCluster); err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Delete Resource",
-			err.Error(),
-		)
-		return
+	ref := m.ClusterTokenSecretRef
+	var secret corev1.Secret
+	if err := CRClient.Get(ctx, kubeTypes.NamespacedName{Name: ref.Name.ValueString(), Namespace: ref.Namespace.ValueString()}, &secret); err != nil {
+		return "", err
 	}
 
+	token, ok := secret.Data[ref.Key.ValueString()]
+	if !ok {
+		return "", fmt.Errorf("the Secret %s/%s does not contain the key %q", ref.Namespace.ValueString(), ref.Name.ValueString(), ref.Key.ValueString())
+	}
+	return string(token), nil
 }
 
-// Configure method to obtain kubernetes Clients provided by provider
-func (p *peeringResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
-		return
+// setForeignClusterSettings sets the model peering settings from the ones configured in the ForeignCluster
+func (m *peeringResourceModel) setForeignClusterSettings(fc *discoveryv1alpha1.ForeignCluster) {
+	m.ClusterProxyURL = types.StringNull()
+	if fc.Spec.ForeignProxyURL != "" {
+		m.ClusterProxyURL = types.StringValue(fc.Spec.ForeignProxyURL)
 	}
-
-	p.config = req.ProviderData.(liqoProviderModel)
+	m.InsecureSkipTLSVerify = types.BoolValue(fc.Spec.InsecureSkipTLSVerify == nil || *fc.Spec.InsecureSkipTLSVerify)
 }
 
-type peeringResourceModel struct {
-	ClusterID      types.String `tfsdk:"cluster_id"`
-	ClusterName    types.String `tfsdk:"cluster_name"`
-	ClusterAuthURL types.String `tfsdk:"cluster_authurl"`
-	ClusterToken   types.String `tfsdk:"cluster_token"`
-	LiqoNamespace  types.String `tfsdk:"liqo_namespace"`
+// setForeignClusterStatus copies into the model the attributes observed on the ForeignCluster and on its virtual node,
+// the name of the virtual node is predicted from the cluster identity as long as the node has not been created yet
+func (m *peeringResourceModel) setForeignClusterStatus(ctx context.Context, CRClient client.Client, fc *discoveryv1alpha1.ForeignCluster) error {
+	m.PeeringType = types.StringValue(string(fc.Spec.PeeringType))
+	m.OutgoingPeeringEnabled = types.StringValue(string(fc.Spec.OutgoingPeeringEnabled))
+	m.IncomingPeeringEnabled = types.StringValue(string(fc.Spec.IncomingPeeringEnabled))
+
+	m.AuthenticationStatus = types.StringValue(string(peeringconditionsutils.GetStatus(fc, discoveryv1alpha1.AuthenticationStatusCondition)))
+	m.IncomingPeeringStatus = types.StringValue(string(peeringconditionsutils.GetStatus(fc, discoveryv1alpha1.IncomingPeeringCondition)))
+	m.OutgoingPeeringStatus = types.StringValue(string(peeringconditionsutils.GetStatus(fc, discoveryv1alpha1.OutgoingPeeringCondition)))
+	m.NetworkStatus = types.StringValue(string(peeringconditionsutils.GetStatus(fc, discoveryv1alpha1.NetworkStatusCondition)))
+	m.LocalTenantNamespace = types.StringValue(fc.Status.TenantNamespace.Local)
+	m.RemoteTenantNamespace = types.StringValue(fc.Status.TenantNamespace.Remote)
+
+	node, err := getters.GetNodeByClusterID(ctx, CRClient, &fc.Spec.ClusterIdentity)
+	switch {
+	case kerrors.IsNotFound(err):
+		m.VirtualNodeName = types.StringValue(virtualKubelet.VirtualNodeName(&fc.Spec.ClusterIdentity))
+	case err != nil:
+		return err
+	default:
+		m.VirtualNodeName = types.StringValue(node.Name)
+	}
+
+	return nil
 }
@@ -2,7 +2,6 @@ package liqo
 
 import (
 	"context"
-	"terraform-provider-liqo/liqo/attribute_plan_modifier"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -15,6 +14,7 @@ import (
 	netv1alpha1 "github.com/liqotech/liqo/apis/net/v1alpha1"
 	offloadingv1alpha1 "github.com/liqotech/liqo/apis/offloading/v1alpha1"
 	sharingv1alpha1 "github.com/liqotech/liqo/apis/sharing/v1alpha1"
+	"github.com/pterm/pterm"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/kubectl/pkg/scheme"
 )
@@ -46,163 +46,117 @@ func (p *liqoProvider) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnost
 		Description: "Interact with Liqo.",
 		Attributes: map[string]tfsdk.Attribute{
 			"kubernetes": {
-				Optional: true,
-				Computed: true,
-				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
-					"host": {
-						Type:     types.StringType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.StringValue("")),
-						},
-						Description: "The hostname (in form of URI) of Kubernetes master.",
-					},
-					"username": {
-						Type:     types.StringType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.StringValue("")),
-						},
-						Description: "The username to use for HTTP basic authentication when accessing the Kubernetes master endpoint.",
-					},
-					"password": {
-						Type:     types.StringType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.StringValue("")),
-						},
-						Description: "The password to use for HTTP basic authentication when accessing the Kubernetes master endpoint.",
-					},
-					"insecure": {
-						Type:     types.BoolType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.BoolValue(false)),
-						},
-						Description: "Whether server should be accessed without verifying the TLS certificate.",
-					},
-					"client_certificate": {
-						Type:     types.StringType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.StringValue("")),
-						},
-						Description: "PEM-encoded client certificate for TLS authentication.",
-					},
-					"client_key": {
-						Type:     types.StringType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.StringValue("")),
-						},
-						Description: "PEM-encoded client certificate key for TLS authentication.",
-					},
-					"cluster_ca_certificate": {
-						Type:     types.StringType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.StringValue("")),
-						},
-						Description: "PEM-encoded root certificates bundle for TLS authentication.",
-					},
-					"config_paths": {
-						Type:     types.ListType{ElemType: types.StringType},
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.ListNull(types.StringType)),
-						},
-					},
-					"config_path": {
-						Type:     types.StringType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.StringValue("")),
-						},
-						Description: "Path to the kube config file. Can be set with KUBE_CONFIG_PATH.",
-					},
-					"config_context": {
-						Type:     types.StringType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.StringValue("")),
-						},
-					},
-					"config_context_auth_info": {
-						Type:     types.StringType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.StringValue("")),
-						},
-						Description: "",
-					},
-					"config_context_cluster": {
-						Type:     types.StringType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.StringValue("")),
-						},
-						Description: "",
-					},
-					"token": {
-						Type:     types.StringType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.StringValue("")),
-						},
-						Description: "Token to authenticate an service account",
-					},
-					"proxy_url": {
-						Type:     types.StringType,
-						Optional: true,
-						PlanModifiers: []tfsdk.AttributePlanModifier{
-							attribute_plan_modifier.DefaultValue(types.StringValue("")),
-						},
-						Description: "URL to the proxy to be used for all API requests",
-					},
-					"exec": {
-						Optional: true,
-						Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
-							"api_version": {
-								Type:     types.StringType,
-								Required: true,
-								PlanModifiers: []tfsdk.AttributePlanModifier{
-									attribute_plan_modifier.DefaultValue(types.StringValue("")),
-								},
-								Validators: []tfsdk.AttributeValidator{
-									stringvalidator.NoneOf("client.authentication.k8s.io/v1alpha1"),
-								},
-							},
-							"command": {
-								Type:     types.StringType,
-								Required: true,
-								PlanModifiers: []tfsdk.AttributePlanModifier{
-									attribute_plan_modifier.DefaultValue(types.StringValue("")),
-								},
-							},
-							"env": {
-								Type:     types.MapType{ElemType: types.StringType},
-								Optional: true,
-								PlanModifiers: []tfsdk.AttributePlanModifier{
-									attribute_plan_modifier.DefaultValue(types.MapNull(types.StringType)),
-								},
-							},
-							"args": {
-								Type:     types.ListType{ElemType: types.StringType},
-								Optional: true,
-								PlanModifiers: []tfsdk.AttributePlanModifier{
-									attribute_plan_modifier.DefaultValue(types.ListNull(types.StringType)),
-								},
-							},
-						}),
-					},
-				}),
+				Optional:   true,
+				Computed:   true,
+				Attributes: tfsdk.SingleNestedAttributes(kubernetesAttributes()),
 			},
 		},
 	}, nil
 }
 
-// Configure method to create the two kubernetes Clients using parameters passed in the provider instantiation in Terraform main
-// After the creation both Clients will be available in resources and data sources
+// kubernetesAttributes returns the attributes describing the connection to a kubernetes cluster
+func kubernetesAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"host": {
+			Type:        types.StringType,
+			Optional:    true,
+			Description: "The hostname (in form of URI) of Kubernetes master.",
+		},
+		"username": {
+			Type:        types.StringType,
+			Optional:    true,
+			Description: "The username to use for HTTP basic authentication when accessing the Kubernetes master endpoint.",
+		},
+		"password": {
+			Type:        types.StringType,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The password to use for HTTP basic authentication when accessing the Kubernetes master endpoint.",
+		},
+		"insecure": {
+			Type:        types.BoolType,
+			Optional:    true,
+			Description: "Whether server should be accessed without verifying the TLS certificate.",
+		},
+		"client_certificate": {
+			Type:        types.StringType,
+			Optional:    true,
+			Description: "PEM-encoded client certificate for TLS authentication.",
+		},
+		"client_key": {
+			Type:        types.StringType,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "PEM-encoded client certificate key for TLS authentication.",
+		},
+		"cluster_ca_certificate": {
+			Type:        types.StringType,
+			Optional:    true,
+			Description: "PEM-encoded root certificates bundle for TLS authentication.",
+		},
+		"config_paths": {
+			Type:     types.ListType{ElemType: types.StringType},
+			Optional: true,
+		},
+		"config_path": {
+			Type:        types.StringType,
+			Optional:    true,
+			Description: "Path to the kube config file. Can be set with KUBE_CONFIG_PATH.",
+		},
+		"config_context": {
+			Type:     types.StringType,
+			Optional: true,
+		},
+		"config_context_auth_info": {
+			Type:        types.StringType,
+			Optional:    true,
+			Description: "",
+		},
+		"config_context_cluster": {
+			Type:        types.StringType,
+			Optional:    true,
+			Description: "",
+		},
+		"token": {
+			Type:        types.StringType,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Token to authenticate an service account",
+		},
+		"proxy_url": {
+			Type:        types.StringType,
+			Optional:    true,
+			Description: "URL to the proxy to be used for all API requests",
+		},
+		"exec": {
+			Optional: true,
+			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"api_version": {
+					Type:     types.StringType,
+					Required: true,
+					Validators: []tfsdk.AttributeValidator{
+						stringvalidator.NoneOf("client.authentication.k8s.io/v1alpha1"),
+					},
+				},
+				"command": {
+					Type:     types.StringType,
+					Required: true,
+				},
+				"env": {
+					Type:     types.MapType{ElemType: types.StringType},
+					Optional: true,
+				},
+				"args": {
+					Type:     types.ListType{ElemType: types.StringType},
+					Optional: true,
+				},
+			}),
+		},
+	}
+}
+
+// Configure method to prepare the two kubernetes Clients using parameters passed in the provider instantiation in Terraform main
+// The Clients are shared by resources and data sources and connect to the cluster only at their first use
 func (p *liqoProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var config liqoProviderModel
 	diags := req.Config.Get(ctx, &config)
@@ -211,16 +165,32 @@ func (p *liqoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	resp.ResourceData = config
+	// The liqoctl logic reused by the resources prints through pterm to the standard output,
+	// which the plugin shares with Terraform, hence the output is disabled once for the whole process
+	pterm.DisableOutput()
+
+	data, err := newLiqoProviderData(config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Configure Provider",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.ResourceData = data
+	resp.DataSourceData = data
 }
 
 func (p *liqoProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewForeignClusterDataSource, NewForeignClustersDataSource, NewClusterIdentityDataSource, NewVirtualNodesDataSource, NewNetworkCheckDataSource, NewResourceOfferDataSource,
+	}
 }
 
 func (p *liqoProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
-		NewPeeringResource, NewGenerateResource, NewOffloadResource,
+		NewPeeringResource, NewGenerateResource, NewOffloadResource, NewBidirectionalPeeringResource, NewInBandPeeringResource, NewInstallResource,
 	}
 }
 
@@ -246,7 +216,7 @@ type kube_conf struct {
 	KUBE_CTX_CLUSTER          types.String   `tfsdk:"config_context_cluster"`
 	KUBE_TOKEN                types.String   `tfsdk:"token"`
 	KUBE_PROXY_URL            types.String   `tfsdk:"proxy_url"`
-	KUBE_EXEC                 []exec         `tfsdk:"exec"`
+	KUBE_EXEC                 *exec          `tfsdk:"exec"`
 }
 
 type liqoProviderModel struct {
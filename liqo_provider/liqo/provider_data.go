@@ -0,0 +1,216 @@
+package liqo
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/liqotech/liqo/pkg/liqoctl/factory"
+	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	"github.com/mitchellh/go-homedir"
+	apimachineryschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// liqoProviderData is built once by the provider Configure method and shared by every resource and data source.
+// The kubernetes Clients are created lazily at their first use, so that planning against a cluster
+// which does not exist yet (e.g. a kind cluster created in the same apply) does not fail.
+type liqoProviderData struct {
+	clientConfig clientcmd.ClientConfig
+
+	mu         sync.Mutex
+	restConfig *rest.Config
+	crClient   client.Client
+	kubeClient *kubernetes.Clientset
+}
+
+// newLiqoProviderData resolves the kubernetes connection parameters without contacting the API server.
+func newLiqoProviderData(config liqoProviderModel) (*liqoProviderData, error) {
+	conf := config.KUBERNETES
+	if conf == nil {
+		conf = &kube_conf{}
+	}
+
+	overrides := &clientcmd.ConfigOverrides{}
+	loader := &clientcmd.ClientConfigLoadingRules{}
+
+	configPaths := []string{}
+
+	if conf.KUBE_CONFIG_PATH.ValueString() != "" {
+		configPaths = []string{conf.KUBE_CONFIG_PATH.ValueString()}
+	} else if len(conf.KUBE_CONFIG_PATHS) > 0 {
+		for _, configPath := range conf.KUBE_CONFIG_PATHS {
+			configPaths = append(configPaths, configPath.ValueString())
+		}
+	} else if v := os.Getenv("KUBE_CONFIG_PATHS"); v != "" {
+		configPaths = filepath.SplitList(v)
+	} else if v := os.Getenv("KUBE_CONFIG_PATH"); v != "" {
+		configPaths = []string{v}
+	}
+
+	if len(configPaths) > 0 {
+		expandedPaths := []string{}
+		for _, p := range configPaths {
+			path, err := homedir.Expand(p)
+			if err != nil {
+				return nil, err
+			}
+			expandedPaths = append(expandedPaths, path)
+		}
+
+		if len(expandedPaths) == 1 {
+			loader.ExplicitPath = expandedPaths[0]
+		} else {
+			loader.Precedence = expandedPaths
+		}
+
+		ctxOk := conf.KUBE_CTX.ValueString() != ""
+		authInfoOk := conf.KUBE_CTX_AUTH_INFO.ValueString() != ""
+		clusterOk := conf.KUBE_CTX_CLUSTER.ValueString() != ""
+
+		if ctxOk || authInfoOk || clusterOk {
+			if ctxOk {
+				overrides.CurrentContext = conf.KUBE_CTX.ValueString()
+			}
+
+			overrides.Context = clientcmdapi.Context{}
+			if authInfoOk {
+				overrides.Context.AuthInfo = conf.KUBE_CTX_AUTH_INFO.ValueString()
+			}
+			if clusterOk {
+				overrides.Context.Cluster = conf.KUBE_CTX_CLUSTER.ValueString()
+			}
+		}
+	}
+
+	if !conf.KUBE_INSECURE.IsNull() {
+		overrides.ClusterInfo.InsecureSkipTLSVerify = conf.KUBE_INSECURE.ValueBool()
+	}
+	if conf.KUBE_CLUSTER_CA_CERT_DATA.ValueString() != "" {
+		overrides.ClusterInfo.CertificateAuthorityData = bytes.NewBufferString(conf.KUBE_CLUSTER_CA_CERT_DATA.ValueString()).Bytes()
+	}
+	if conf.KUBE_CLIENT_CERT_DATA.ValueString() != "" {
+		overrides.AuthInfo.ClientCertificateData = bytes.NewBufferString(conf.KUBE_CLIENT_CERT_DATA.ValueString()).Bytes()
+	}
+	if conf.KUBE_HOST.ValueString() != "" {
+		hasCA := len(overrides.ClusterInfo.CertificateAuthorityData) != 0
+		hasCert := len(overrides.AuthInfo.ClientCertificateData) != 0
+		defaultTLS := hasCA || hasCert || overrides.ClusterInfo.InsecureSkipTLSVerify
+		host, _, err := rest.DefaultServerURL(conf.KUBE_HOST.ValueString(), "", apimachineryschema.GroupVersion{}, defaultTLS)
+		if err != nil {
+			return nil, err
+		}
+
+		overrides.ClusterInfo.Server = host.String()
+	}
+	if conf.KUBE_USER.ValueString() != "" {
+		overrides.AuthInfo.Username = conf.KUBE_USER.ValueString()
+	}
+	if conf.KUBE_PASSWORD.ValueString() != "" {
+		overrides.AuthInfo.Password = conf.KUBE_PASSWORD.ValueString()
+	}
+	if conf.KUBE_CLIENT_KEY_DATA.ValueString() != "" {
+		overrides.AuthInfo.ClientKeyData = bytes.NewBufferString(conf.KUBE_CLIENT_KEY_DATA.ValueString()).Bytes()
+	}
+	if conf.KUBE_TOKEN.ValueString() != "" {
+		overrides.AuthInfo.Token = conf.KUBE_TOKEN.ValueString()
+	}
+
+	if conf.KUBE_PROXY_URL.ValueString() != "" {
+		overrides.ClusterDefaults.ProxyURL = conf.KUBE_PROXY_URL.ValueString()
+	}
+
+	if conf.KUBE_EXEC != nil {
+		exec := &clientcmdapi.ExecConfig{}
+		exec.InteractiveMode = clientcmdapi.IfAvailableExecInteractiveMode
+		exec.APIVersion = conf.KUBE_EXEC.API_VERSION.ValueString()
+		exec.Command = conf.KUBE_EXEC.COMMAND.ValueString()
+		for _, arg := range conf.KUBE_EXEC.ARGS {
+			exec.Args = append(exec.Args, arg.ValueString())
+		}
+
+		for kk, vv := range conf.KUBE_EXEC.ENV.Elements() {
+			if v, ok := vv.(types.String); ok {
+				exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: kk, Value: v.ValueString()})
+			}
+		}
+
+		overrides.AuthInfo.Exec = exec
+	}
+
+	clientCfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
+	if clientCfg == nil {
+		return nil, fmt.Errorf("unable to create the kubernetes client configuration")
+	}
+
+	return &liqoProviderData{clientConfig: clientCfg}, nil
+}
+
+// Clients returns the kubernetes Clients, creating them at the first invocation.
+// A failed attempt is not cached, hence a later call can succeed once the cluster becomes reachable.
+func (d *liqoProviderData) Clients() (client.Client, *kubernetes.Clientset, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.crClient != nil && d.kubeClient != nil {
+		return d.crClient, d.kubeClient, nil
+	}
+
+	restCfg, err := d.clientConfig.ClientConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mapper, err := apiutil.NewDynamicRESTMapper(restCfg, apiutil.WithLazyDiscovery)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	CRClient, err := client.New(restCfg, client.Options{Scheme: scheme.Scheme, Mapper: mapper})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	KubeClient, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d.restConfig = restCfg
+	d.crClient = CRClient
+	d.kubeClient = KubeClient
+
+	return d.crClient, d.kubeClient, nil
+}
+
+// Factory returns a liqoctl Factory sharing the kubernetes Clients, so that the logic of the liqoctl commands can be reused.
+// The output of its printers is disabled by the provider Configure.
+func (d *liqoProviderData) Factory(liqoNamespace string, remote bool) (*factory.Factory, error) {
+	CRClient, KubeClient, err := d.Clients()
+	if err != nil {
+		return nil, err
+	}
+
+	printer := output.NewLocalPrinter(true, false)
+	if remote {
+		printer = output.NewRemotePrinter(true, false)
+	}
+
+	return &factory.Factory{
+		Printer:       printer,
+		Namespace:     liqoNamespace,
+		LiqoNamespace: liqoNamespace,
+		RESTConfig:    d.restConfig,
+		CRClient:      CRClient,
+		KubeClient:    KubeClient,
+	}, nil
+}
@@ -0,0 +1,223 @@
+package liqo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sharingv1alpha1 "github.com/liqotech/liqo/apis/sharing/v1alpha1"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	_ datasource.DataSource              = &resourceOfferDataSource{}
+	_ datasource.DataSourceWithConfigure = &resourceOfferDataSource{}
+)
+
+func NewResourceOfferDataSource() datasource.DataSource {
+	return &resourceOfferDataSource{}
+}
+
+type resourceOfferDataSource struct {
+	data *liqoProviderData
+}
+
+func (d *resourceOfferDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource_offer"
+}
+
+func (d *resourceOfferDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "Read the ResourceOffer received from a foreign cluster.",
+		Attributes: map[string]tfsdk.Attribute{
+			"cluster_id": {
+				Type:        types.StringType,
+				Required:    true,
+				Description: "ID of the foreign cluster which sent the offer.",
+			},
+			"minimum": {
+				Type:        types.MapType{ElemType: types.StringType},
+				Optional:    true,
+				Description: "Minimum quantities the offer is expected to provide (e.g. cpu = \"4\", memory = \"8Gi\"), a warning is emitted for each one which is not met.",
+			},
+			"name": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Name of the ResourceOffer.",
+			},
+			"phase": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Phase of the ResourceOffer (Pending, ManualActionRequired, Accepted or Refused).",
+			},
+			"virtual_kubelet_status": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Status of the virtual kubelet created for the offer (None, Created or Deleting).",
+			},
+			"cpu": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "CPU offered by the foreign cluster.",
+			},
+			"memory": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Memory offered by the foreign cluster.",
+			},
+			"pods": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Number of pods offered by the foreign cluster.",
+			},
+			"ephemeral_storage": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Ephemeral storage offered by the foreign cluster.",
+			},
+			"resources": {
+				Type:        types.MapType{ElemType: types.StringType},
+				Computed:    true,
+				Description: "All the resources offered by the foreign cluster.",
+			},
+			"sufficient": {
+				Type:        types.BoolType,
+				Computed:    true,
+				Description: "Whether the offer satisfies all the minimum quantities.",
+			},
+		},
+	}, nil
+}
+
+func (d *resourceOfferDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config resourceOfferDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	minimum := corev1.ResourceList{}
+	for name, value := range config.Minimum {
+		quantity, err := resource.ParseQuantity(value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("minimum").AtMapKey(name),
+				"Invalid Quantity",
+				err.Error(),
+			)
+			continue
+		}
+		minimum[corev1.ResourceName(name)] = quantity
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	CRClient, _, err := d.data.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			err.Error(),
+		)
+		return
+	}
+
+	offer, err := getters.GetResourceOfferByLabel(ctx, CRClient, metav1.NamespaceAll,
+		liqolabels.RemoteLabelSelectorForCluster(config.ClusterID.ValueString()))
+	if kerrors.IsNotFound(err) {
+		err = fmt.Errorf("no ResourceOffer received from cluster %s, check that the outgoing peering is established", config.ClusterID.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			err.Error(),
+		)
+		return
+	}
+
+	state := resourceOfferModelFrom(offer)
+	state.ClusterID = config.ClusterID
+	state.Minimum = config.Minimum
+
+	for _, name := range insufficientResources(offer.Spec.ResourceQuota.Hard, minimum) {
+		offered := offer.Spec.ResourceQuota.Hard[name]
+		required := minimum[name]
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("minimum").AtMapKey(string(name)),
+			"Insufficient Resource Offer",
+			fmt.Sprintf("Cluster %s offers %s of %s, while at least %s is required.",
+				config.ClusterID.ValueString(), offered.String(), name, required.String()),
+		)
+		state.Sufficient = types.BoolValue(false)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure method to obtain kubernetes Clients provided by provider
+func (d *resourceOfferDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.data = req.ProviderData.(*liqoProviderData)
+}
+
+// resourceOfferModelFrom converts a ResourceOffer into its terraform model
+func resourceOfferModelFrom(offer *sharingv1alpha1.ResourceOffer) resourceOfferDataSourceModel {
+	offered := offer.Spec.ResourceQuota.Hard
+	return resourceOfferDataSourceModel{
+		Name:                 types.StringValue(offer.Name),
+		Phase:                types.StringValue(string(offer.Status.Phase)),
+		VirtualKubeletStatus: types.StringValue(string(offer.Status.VirtualKubeletStatus)),
+		CPU:                  types.StringValue(offered.Cpu().String()),
+		Memory:               types.StringValue(offered.Memory().String()),
+		Pods:                 types.StringValue(offered.Pods().String()),
+		EphemeralStorage:     types.StringValue(offered.StorageEphemeral().String()),
+		Resources:            resourceListModel(offered),
+		Sufficient:           types.BoolValue(true),
+	}
+}
+
+// insufficientResources returns the sorted names of the resources offered in
+// a smaller quantity than the minimum, missing resources count as zero
+func insufficientResources(offered, minimum corev1.ResourceList) []corev1.ResourceName {
+	var names []corev1.ResourceName
+	for name, required := range minimum {
+		quantity := offered[name]
+		if quantity.Cmp(required) < 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+type resourceOfferDataSourceModel struct {
+	ClusterID            types.String            `tfsdk:"cluster_id"`
+	Minimum              map[string]types.String `tfsdk:"minimum"`
+	Name                 types.String            `tfsdk:"name"`
+	Phase                types.String            `tfsdk:"phase"`
+	VirtualKubeletStatus types.String            `tfsdk:"virtual_kubelet_status"`
+	CPU                  types.String            `tfsdk:"cpu"`
+	Memory               types.String            `tfsdk:"memory"`
+	Pods                 types.String            `tfsdk:"pods"`
+	EphemeralStorage     types.String            `tfsdk:"ephemeral_storage"`
+	Resources            map[string]types.String `tfsdk:"resources"`
+	Sufficient           types.Bool              `tfsdk:"sufficient"`
+}
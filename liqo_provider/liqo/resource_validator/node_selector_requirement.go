@@ -0,0 +1,87 @@
+package resource_validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type nodeSelectorRequirementValidator struct {
+	expression path.Expression
+}
+
+// NodeSelectorRequirement checks that the values of the node selector requirements matching the given expression
+// are consistent with their operator, each requirement must have an operator and a values attribute
+func NodeSelectorRequirement(expression path.Expression) resource.ConfigValidator {
+	return &nodeSelectorRequirementValidator{expression: expression}
+}
+
+var _ resource.ConfigValidator = (*nodeSelectorRequirementValidator)(nil)
+
+func (v *nodeSelectorRequirementValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v *nodeSelectorRequirementValidator) MarkdownDescription(_ context.Context) string {
+	return "Values must be empty when the operator is Exists or DoesNotExist, non-empty when it is In or NotIn, and a single element when it is Gt or Lt"
+}
+
+func (v *nodeSelectorRequirementValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	paths, diags := req.Config.PathMatches(ctx, v.expression)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, p := range paths {
+		// the closest existing parent is returned when the requirements list is null
+		if !v.expression.Matches(p) {
+			continue
+		}
+
+		var operator types.String
+		diags := req.Config.GetAttribute(ctx, p.AtName("operator"), &operator)
+
+		var values types.List
+		diags.Append(req.Config.GetAttribute(ctx, p.AtName("values"), &values)...)
+
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		if operator.IsNull() || operator.IsUnknown() || values.IsUnknown() {
+			continue
+		}
+
+		count := len(values.Elements())
+
+		var err error
+		switch op := corev1.NodeSelectorOperator(operator.ValueString()); op {
+		case corev1.NodeSelectorOpExists, corev1.NodeSelectorOpDoesNotExist:
+			if count != 0 {
+				err = fmt.Errorf("values must be empty when the operator is %s, got %d values", op, count)
+			}
+		case corev1.NodeSelectorOpIn, corev1.NodeSelectorOpNotIn:
+			if count == 0 {
+				err = fmt.Errorf("values must be non-empty when the operator is %s", op)
+			}
+		case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+			if count != 1 {
+				err = fmt.Errorf("values must contain a single element when the operator is %s, got %d values", op, count)
+			}
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				p.AtName("values"),
+				"Invalid Node Selector Requirement",
+				err.Error(),
+			)
+		}
+	}
+}
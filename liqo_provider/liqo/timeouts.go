@@ -0,0 +1,81 @@
+package liqo
+
+import (
+	"terraform-provider-liqo/liqo/attribute_validator"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timeoutsAttribute returns the schema of the timeouts attribute shared by resources waiting for Liqo to converge
+func timeoutsAttribute() tfsdk.Attribute {
+	return tfsdk.Attribute{
+		Optional: true,
+		Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+			"create": {
+				Type:     types.StringType,
+				Optional: true,
+				Validators: []tfsdk.AttributeValidator{
+					attribute_validator.Duration(),
+				},
+				Description: "Timeout for the creation (e.g. \"30s\", \"5m\").",
+			},
+			"update": {
+				Type:     types.StringType,
+				Optional: true,
+				Validators: []tfsdk.AttributeValidator{
+					attribute_validator.Duration(),
+				},
+				Description: "Timeout for the update (e.g. \"30s\", \"5m\").",
+			},
+			"delete": {
+				Type:     types.StringType,
+				Optional: true,
+				Validators: []tfsdk.AttributeValidator{
+					attribute_validator.Duration(),
+				},
+				Description: "Timeout for the deletion (e.g. \"30s\", \"5m\").",
+			},
+		}),
+		Description: "Timeouts of the operations waiting for Liqo.",
+	}
+}
+
+const defaultTimeout = 5 * time.Minute
+
+type timeouts struct {
+	Create types.String `tfsdk:"create"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+func (t *timeouts) CreateTimeout() time.Duration {
+	if t == nil {
+		return defaultTimeout
+	}
+	return parseTimeout(t.Create)
+}
+
+func (t *timeouts) UpdateTimeout() time.Duration {
+	if t == nil {
+		return defaultTimeout
+	}
+	return parseTimeout(t.Update)
+}
+
+func (t *timeouts) DeleteTimeout() time.Duration {
+	if t == nil {
+		return defaultTimeout
+	}
+	return parseTimeout(t.Delete)
+}
+
+// parseTimeout returns the configured timeout, the value has already been checked by the Duration validator
+func parseTimeout(value types.String) time.Duration {
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return defaultTimeout
+	}
+	return timeout
+}
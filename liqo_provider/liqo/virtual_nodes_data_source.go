@@ -0,0 +1,182 @@
+package liqo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/liqotech/liqo/pkg/consts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	_ datasource.DataSource              = &virtualNodesDataSource{}
+	_ datasource.DataSourceWithConfigure = &virtualNodesDataSource{}
+)
+
+func NewVirtualNodesDataSource() datasource.DataSource {
+	return &virtualNodesDataSource{}
+}
+
+type virtualNodesDataSource struct {
+	data *liqoProviderData
+}
+
+func (d *virtualNodesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_nodes"
+}
+
+func (d *virtualNodesDataSource) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "List the virtual nodes created by Liqo.",
+		Attributes: map[string]tfsdk.Attribute{
+			"label_selector": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "Label selector to filter the virtual nodes (e.g. \"liqo.io/provider=kind,topology.kubernetes.io/region in (europe)\").",
+			},
+			"virtual_nodes": {
+				Computed: true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"name": {
+						Type:        types.StringType,
+						Computed:    true,
+						Description: "Name of the virtual node.",
+					},
+					"cluster_id": {
+						Type:        types.StringType,
+						Computed:    true,
+						Description: "ID of the remote cluster represented by the virtual node.",
+					},
+					"labels": {
+						Type:        types.MapType{ElemType: types.StringType},
+						Computed:    true,
+						Description: "Labels of the virtual node.",
+					},
+					"capacity": {
+						Type:        types.MapType{ElemType: types.StringType},
+						Computed:    true,
+						Description: "Resources offered by the remote cluster (e.g. cpu, memory, pods).",
+					},
+					"allocatable": {
+						Type:        types.MapType{ElemType: types.StringType},
+						Computed:    true,
+						Description: "Resources of the virtual node available for scheduling.",
+					},
+				}),
+				Description: "Virtual nodes matching the label selector.",
+			},
+		},
+	}, nil
+}
+
+func (d *virtualNodesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config virtualNodesDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	selector, err := labels.Parse(config.LabelSelector.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("label_selector"),
+			"Invalid Label Selector",
+			err.Error(),
+		)
+		return
+	}
+
+	virtualNode, err := labels.NewRequirement(consts.TypeLabel, selection.Equals, []string{consts.TypeNode})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			err.Error(),
+		)
+		return
+	}
+	selector = selector.Add(*virtualNode)
+
+	CRClient, _, err := d.data.Clients()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			err.Error(),
+		)
+		return
+	}
+
+	var nodes corev1.NodeList
+	if err := CRClient.List(ctx, &nodes, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Source",
+			err.Error(),
+		)
+		return
+	}
+
+	state := virtualNodesDataSourceModel{
+		LabelSelector: config.LabelSelector,
+		VirtualNodes:  []virtualNodeModel{},
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+
+		nodeLabels := map[string]types.String{}
+		for key, value := range node.Labels {
+			nodeLabels[key] = types.StringValue(value)
+		}
+
+		state.VirtualNodes = append(state.VirtualNodes, virtualNodeModel{
+			Name:        types.StringValue(node.Name),
+			ClusterID:   types.StringValue(node.Labels[consts.RemoteClusterID]),
+			Labels:      nodeLabels,
+			Capacity:    resourceListModel(node.Status.Capacity),
+			Allocatable: resourceListModel(node.Status.Allocatable),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure method to obtain kubernetes Clients provided by provider
+func (d *virtualNodesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.data = req.ProviderData.(*liqoProviderData)
+}
+
+// resourceListModel converts a list of resources into a map of their quantities
+func resourceListModel(resources corev1.ResourceList) map[string]types.String {
+	quantities := map[string]types.String{}
+	for name, quantity := range resources {
+		quantities[string(name)] = types.StringValue(quantity.String())
+	}
+	return quantities
+}
+
+type virtualNodeModel struct {
+	Name        types.String            `tfsdk:"name"`
+	ClusterID   types.String            `tfsdk:"cluster_id"`
+	Labels      map[string]types.String `tfsdk:"labels"`
+	Capacity    map[string]types.String `tfsdk:"capacity"`
+	Allocatable map[string]types.String `tfsdk:"allocatable"`
+}
+
+type virtualNodesDataSourceModel struct {
+	LabelSelector types.String       `tfsdk:"label_selector"`
+	VirtualNodes  []virtualNodeModel `tfsdk:"virtual_nodes"`
+}